@@ -0,0 +1,82 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	bankDetailsLookupEndpoint = "bank_details_lookups"
+)
+
+type (
+	// BankDetailsLookup Look up the name and reachability of a bank account, before creating
+	// a customer bank account with it
+	BankDetailsLookup struct {
+		// AvailableDebitSchemes Array of schemes supported for this bank account, e.g. "bacs".
+		// This will be an empty array if the bank account is not reachable by any schemes.
+		AvailableDebitSchemes []string `json:"available_debit_schemes"`
+		// BankName The name of the bank with which the account is held (if available)
+		BankName string `json:"bank_name"`
+		// BIC ISO 9362 SWIFT BIC of the bank with which the account is held
+		BIC string `json:"bic"`
+	}
+
+	// BankDetailsLookupParams the bank details to look up, either the local details or an IBAN
+	BankDetailsLookupParams struct {
+		// AccountHolderName Name of the account holder, used to check the name matches for some schemes
+		AccountHolderName string `json:"account_holder_name,omitempty"`
+		// AccountNumber Bank account number. Alternatively you can provide an iban
+		AccountNumber string `json:"account_number,omitempty"`
+		// BankCode Bank code
+		BankCode string `json:"bank_code,omitempty"`
+		// BranchCode Branch code
+		BranchCode string `json:"branch_code,omitempty"`
+		// CountryCode is the ISO 3166-1 alpha-2 code, required with local details
+		CountryCode string `json:"country_code,omitempty"`
+		// IBAN International Bank Account Number
+		IBAN string `json:"iban,omitempty"`
+	}
+
+	// bankDetailsLookupWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	bankDetailsLookupWrapper struct {
+		BankDetailsLookup *BankDetailsLookup `json:"bank_details_lookups"`
+	}
+)
+
+func (l *BankDetailsLookup) String() string {
+	bs, _ := json.Marshal(l)
+	return string(bs)
+}
+
+// SupportsScheme reports whether the bank account can be debited with scheme
+func (l *BankDetailsLookup) SupportsScheme(scheme string) bool {
+	for _, s := range l.AvailableDebitSchemes {
+		if s == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupBankDetails performs a bank details lookup. It returns a ValidationFailedError when
+// the details are invalid, e.g. a wrong sort code or IBAN check digits.
+//
+// Relative endpoint: POST /bank_details_lookups
+func (c *Client) LookupBankDetails(params *BankDetailsLookupParams) (*BankDetailsLookup, error) {
+	return c.LookupBankDetailsContext(context.Background(), params)
+}
+
+// LookupBankDetailsContext is the same as LookupBankDetails, but uses ctx for the request and applies opts to it.
+func (c *Client) LookupBankDetailsContext(ctx context.Context, params *BankDetailsLookupParams, opts ...RequestOption) (*BankDetailsLookup, error) {
+	body := map[string]interface{}{
+		"bank_details_lookups": params,
+	}
+
+	wrapper := &bankDetailsLookupWrapper{}
+	err := c.post(ctx, bankDetailsLookupEndpoint, body, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.BankDetailsLookup, err
+}
@@ -0,0 +1,132 @@
+package gocardless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// lookupHandler answers bank details lookups with lookup, or a validation error when lookup
+// is empty, and creates customer bank accounts
+func lookupHandler(paths *[]string, lookup string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/bank_details_lookups":
+			if lookup == "" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"error":{"type":"validation_failed","code":422,"errors":[{"field":"branch_code","message":"is invalid"}]}}`))
+				return
+			}
+			w.Write([]byte(lookup))
+		case "/customer_bank_accounts":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"customer_bank_accounts":{"id":"BA123"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestLookupBankDetails(t *testing.T) {
+	var body map[string]map[string]interface{}
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"bank_details_lookups":{"available_debit_schemes":["bacs"],"bank_name":"BARCLAYS BANK PLC","bic":"BARCGB22XXX"}}`))
+	})
+	defer srv.Close()
+
+	lookup, err := c.LookupBankDetails(&BankDetailsLookupParams{AccountNumber: "55779911", BranchCode: "200000", CountryCode: "GB"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body["bank_details_lookups"]["branch_code"] != "200000" {
+		t.Errorf("unexpected body %v", body)
+	}
+	if lookup.BankName != "BARCLAYS BANK PLC" || !lookup.SupportsScheme("bacs") || lookup.SupportsScheme("sepa_core") {
+		t.Errorf("unexpected lookup %v", lookup)
+	}
+}
+
+func TestCreateCustomerBankAccountLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		lookup string
+		paths  string
+		check  func(err error) bool
+	}{
+		{
+			name:   "debitable",
+			lookup: `{"bank_details_lookups":{"available_debit_schemes":["bacs"],"bank_name":"BARCLAYS BANK PLC"}}`,
+			paths:  "[/bank_details_lookups /customer_bank_accounts]",
+			check:  func(err error) bool { return err == nil },
+		},
+		{
+			name:   "no debit scheme",
+			lookup: `{"bank_details_lookups":{"available_debit_schemes":[],"bank_name":"BARCLAYS BANK PLC"}}`,
+			paths:  "[/bank_details_lookups]",
+			check: func(err error) bool {
+				var target *NoDebitSchemeError
+				return errors.As(err, &target) && target.Lookup.BankName == "BARCLAYS BANK PLC" &&
+					errors.Is(err, ErrAvailableDebitSchemeNotFound)
+			},
+		},
+		{
+			name:  "invalid details",
+			paths: "[/bank_details_lookups]",
+			check: func(err error) bool {
+				var target *ValidationFailedError
+				return errors.As(err, &target)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			c, srv := newTestClient(t, lookupHandler(&paths, tt.lookup), WithBankDetailsLookup())
+			defer srv.Close()
+
+			err := c.CreateCustomerBankAccount(NewCustomerBankAccount("55779911", "Frank Osborne", "200000", "GB", "CU123"))
+			if !tt.check(err) {
+				t.Errorf("unexpected error %#v", err)
+			}
+			if got := fmt.Sprint(paths); got != tt.paths {
+				t.Errorf("expected requests %s, got %s", tt.paths, got)
+			}
+		})
+	}
+}
+
+func TestCreateCustomerBankAccountWithoutLookup(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ClientOption
+		cba  *CustomerBankAccount
+	}{
+		{
+			name: "lookup disabled",
+			cba:  NewCustomerBankAccount("55779911", "Frank Osborne", "200000", "GB", "CU123"),
+		},
+		{
+			name: "customer bank account token",
+			opts: []ClientOption{WithBankDetailsLookup()},
+			cba:  &CustomerBankAccount{Links: customerLinks{CustomerBankAccountToken: "BAT123"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			c, srv := newTestClient(t, lookupHandler(&paths, ""), tt.opts...)
+			defer srv.Close()
+
+			if err := c.CreateCustomerBankAccount(tt.cba); err != nil {
+				t.Fatal(err)
+			}
+			if got := fmt.Sprint(paths); got != "[/customer_bank_accounts]" {
+				t.Errorf("expected no lookup, got %s", got)
+			}
+		})
+	}
+}
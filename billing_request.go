@@ -0,0 +1,512 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	billingRequestEndpoint = "billing_requests"
+)
+
+// Statuses of billing requests, see BillingRequest.Status
+const (
+	BillingRequestStatusPending       = "pending"
+	BillingRequestStatusReadyToFulfil = "ready_to_fulfil"
+	BillingRequestStatusFulfilling    = "fulfilling"
+	BillingRequestStatusFulfilled     = "fulfilled"
+	BillingRequestStatusCancelled     = "cancelled"
+)
+
+// Types of the actions of a billing request, see BillingRequestAction.Type
+const (
+	BillingRequestActionChooseCurrency         = "choose_currency"
+	BillingRequestActionCollectAmount          = "collect_amount"
+	BillingRequestActionCollectCustomerDetails = "collect_customer_details"
+	BillingRequestActionCollectBankAccount     = "collect_bank_account"
+	BillingRequestActionBankAuthorisation      = "bank_authorisation"
+	BillingRequestActionConfirmPayerDetails    = "confirm_payer_details"
+	BillingRequestActionSelectInstitution      = "select_institution"
+)
+
+// Statuses of the actions of a billing request, see BillingRequestAction.Status
+const (
+	BillingRequestActionStatusPending   = "pending"
+	BillingRequestActionStatusCompleted = "completed"
+)
+
+type (
+	// BillingRequest Billing Requests help create resources that require input or action from a customer,
+	// such as a mandate through MandateRequest and a payment through PaymentRequest. The Actions
+	// describe what is needed before the billing request can be fulfilled, see NextAction.
+	BillingRequest struct {
+		// ID is a unique identifier, beginning with "BRQ".
+		ID string `json:"id,omitempty"`
+		// Actions the actions which can be or need to be completed by the payer or the integrator
+		Actions []*BillingRequestAction `json:"actions,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the billing request was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// FallbackEnabled whether the billing request can fall back from bank authorisation
+		// to a direct debit mandate, see FallbackBillingRequest
+		FallbackEnabled bool `json:"fallback_enabled,omitempty"`
+		// FallbackOccurred whether a fallback happened
+		FallbackOccurred bool `json:"fallback_occurred,omitempty"`
+		// MandateRequest the mandate to set up, at least one of MandateRequest and PaymentRequest is required
+		MandateRequest *BillingRequestMandateRequest `json:"mandate_request,omitempty"`
+		// Metadata is a key-value store of custom data. Up to 3 keys are permitted, with key names up to 50
+		// characters and values up to 500 characters.
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// PaymentRequest the payment to collect, at least one of MandateRequest and PaymentRequest is required
+		PaymentRequest *BillingRequestPaymentRequest `json:"payment_request,omitempty"`
+		// PurposeCode the purpose of the payments, required for some schemes, e.g. "mortgage" or "utility"
+		PurposeCode string `json:"purpose_code,omitempty"`
+		// Resources the customer, bank account and billing details collected so far
+		Resources *BillingRequestResources `json:"resources,omitempty"`
+		// Status status of billing request, one of the BillingRequestStatus constants
+		Status string `json:"status,omitempty"`
+		// Links links to the creditor, the customer and, once fulfilled, the created mandate
+		// and payment in MandateRequestMandateID and PaymentRequestPaymentID
+		Links billingRequestLinks `json:"links"`
+	}
+	billingRequestLinks struct {
+		BankAuthorisationID     string `json:"bank_authorisation,omitempty"`
+		CreditorID              string `json:"creditor,omitempty"`
+		CustomerID              string `json:"customer,omitempty"`
+		CustomerBankAccountID   string `json:"customer_bank_account,omitempty"`
+		CustomerBillingDetailID string `json:"customer_billing_detail,omitempty"`
+		MandateRequestID        string `json:"mandate_request,omitempty"`
+		MandateRequestMandateID string `json:"mandate_request_mandate,omitempty"`
+		OrganisationID          string `json:"organisation,omitempty"`
+		PaymentRequestID        string `json:"payment_request,omitempty"`
+		PaymentRequestPaymentID string `json:"payment_request_payment,omitempty"`
+	}
+
+	// BillingRequestMandateRequest the mandate a billing request sets up
+	BillingRequestMandateRequest struct {
+		// Currency currency code of the mandate
+		Currency string `json:"currency"`
+		// Description A human-readable description of the mandate, shown to the payer
+		Description string `json:"description,omitempty"`
+		// Metadata is a key-value store of custom data, copied to the mandate
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// Scheme Direct Debit scheme of the mandate, guessed from the currency when not set
+		Scheme string `json:"scheme,omitempty"`
+		// Verify how the payer's bank account is verified, one of "minimum",
+		// "recommended", "when_available" or "always"
+		Verify string `json:"verify,omitempty"`
+	}
+
+	// BillingRequestPaymentRequest the payment a billing request collects
+	BillingRequestPaymentRequest struct {
+		// Amount in pence (GBP), cents (EUR)
+		Amount int `json:"amount"`
+		// AppFee The amount to be deducted from the payment as the OAuth app’s fee
+		AppFee int `json:"app_fee,omitempty"`
+		// Currency currency code of the payment
+		Currency string `json:"currency"`
+		// Description A human-readable description of the payment, shown to the payer
+		Description string `json:"description,omitempty"`
+		// Metadata is a key-value store of custom data, copied to the payment
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// Scheme the scheme of the payment, e.g. "faster_payments"
+		Scheme string `json:"scheme,omitempty"`
+	}
+
+	// BillingRequestResources the resources collected by a billing request
+	BillingRequestResources struct {
+		Customer              *Customer                            `json:"customer,omitempty"`
+		CustomerBankAccount   *CustomerBankAccount                 `json:"customer_bank_account,omitempty"`
+		CustomerBillingDetail *BillingRequestCustomerBillingDetail `json:"customer_billing_detail,omitempty"`
+	}
+
+	// BillingRequestAction an action of a billing request, completed by the payer on the hosted
+	// pages or by the integrator with the billing request action methods
+	BillingRequestAction struct {
+		// Type type of the action, one of the BillingRequestAction constants
+		Type string `json:"type"`
+		// Required whether the action must be completed before the billing request can be fulfilled
+		Required bool `json:"required"`
+		// Status status of the action, "pending" or "completed"
+		Status string `json:"status"`
+		// CompletesActions the types of the actions completed along with this one
+		CompletesActions []string `json:"completes_actions,omitempty"`
+		// RequiresActions the types of the actions which must be completed before this one
+		RequiresActions []string `json:"requires_actions,omitempty"`
+		// BankAuthorisation details of a bank_authorisation action
+		BankAuthorisation *BillingRequestBankAuthorisationAction `json:"bank_authorisation,omitempty"`
+		// CollectCustomerDetails details of a collect_customer_details action
+		CollectCustomerDetails *BillingRequestCollectCustomerDetailsAction `json:"collect_customer_details,omitempty"`
+	}
+	// BillingRequestBankAuthorisationAction how the payer authorises the billing request with their bank
+	BillingRequestBankAuthorisationAction struct {
+		// AuthorisationType "single_payment" or "mandate"
+		AuthorisationType string `json:"authorisation_type"`
+		// RequiresInstitution whether an institution must be selected first
+		RequiresInstitution bool `json:"requires_institution"`
+	}
+	// BillingRequestCollectCustomerDetailsAction the customer details still missing
+	BillingRequestCollectCustomerDetailsAction struct {
+		// DefaultCountryCode the country code used when none is collected
+		DefaultCountryCode string `json:"default_country_code,omitempty"`
+		// IncompleteFields the missing fields, keyed by "customer" and "customer_billing_detail"
+		IncompleteFields map[string][]string `json:"incomplete_fields,omitempty"`
+	}
+
+	// BillingRequestCustomer contact details of the payer, see CollectBillingRequestCustomerDetails
+	BillingRequestCustomer struct {
+		CompanyName string            `json:"company_name,omitempty"`
+		Email       string            `json:"email,omitempty"`
+		FamilyName  string            `json:"family_name,omitempty"`
+		GivenName   string            `json:"given_name,omitempty"`
+		Language    string            `json:"language,omitempty"`
+		Metadata    map[string]string `json:"metadata,omitempty"`
+		PhoneNumber string            `json:"phone_number,omitempty"`
+	}
+	// BillingRequestCustomerBillingDetail address of the payer, see CollectBillingRequestCustomerDetails
+	BillingRequestCustomerBillingDetail struct {
+		ID                    string `json:"id,omitempty"`
+		AddressLine1          string `json:"address_line1,omitempty"`
+		AddressLine2          string `json:"address_line2,omitempty"`
+		AddressLine3          string `json:"address_line3,omitempty"`
+		City                  string `json:"city,omitempty"`
+		CountryCode           string `json:"country_code,omitempty"`
+		DanishIdentityNumber  string `json:"danish_identity_number,omitempty"`
+		IPAddress             string `json:"ip_address,omitempty"`
+		PostalCode            string `json:"postal_code,omitempty"`
+		Region                string `json:"region,omitempty"`
+		SwedishIdentityNumber string `json:"swedish_identity_number,omitempty"`
+	}
+	// BillingRequestCustomerDetails the data of the collect_customer_details action
+	BillingRequestCustomerDetails struct {
+		Customer              *BillingRequestCustomer              `json:"customer,omitempty"`
+		CustomerBillingDetail *BillingRequestCustomerBillingDetail `json:"customer_billing_detail,omitempty"`
+	}
+	// BillingRequestBankAccount the data of the collect_bank_account action, either the local
+	// details or an IBAN
+	BillingRequestBankAccount struct {
+		AccountHolderName   string            `json:"account_holder_name"`
+		AccountNumber       string            `json:"account_number,omitempty"`
+		AccountNumberSuffix string            `json:"account_number_suffix,omitempty"`
+		AccountType         string            `json:"account_type,omitempty"`
+		BankCode            string            `json:"bank_code,omitempty"`
+		BranchCode          string            `json:"branch_code,omitempty"`
+		CountryCode         string            `json:"country_code,omitempty"`
+		Currency            string            `json:"currency,omitempty"`
+		IBAN                string            `json:"iban,omitempty"`
+		Metadata            map[string]string `json:"metadata,omitempty"`
+	}
+
+	// billingRequestWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	billingRequestWrapper struct {
+		BillingRequest *BillingRequest `json:"billing_requests"`
+	}
+
+	// BillingRequestListParams parameters for listing billing requests, nil lists the first page
+	BillingRequestListParams struct {
+		ListParams
+		// CreatedAt limits to billing requests created within the range
+		CreatedAt TimeRange
+		// Customer ID of a customer to filter billing requests by
+		Customer string
+		// Status status of the billing requests to return, e.g. "pending"
+		Status string
+	}
+
+	// BillingRequestListResponse a List response of BillingRequest instances
+	BillingRequestListResponse struct {
+		BillingRequests []*BillingRequest `json:"billing_requests"`
+		Meta            Meta              `json:"meta,omitempty"`
+	}
+
+	// BillingRequestIterator iterates over billing requests, see Client.IterateBillingRequests
+	BillingRequestIterator struct {
+		*Iter
+	}
+)
+
+func (br *BillingRequest) String() string {
+	bs, _ := json.Marshal(br)
+	return string(bs)
+}
+
+// NewBillingRequest instantiate a new billing request object, set its MandateRequest
+// and/or PaymentRequest before creating it
+func NewBillingRequest() *BillingRequest {
+	return &BillingRequest{}
+}
+
+// AddMetadata adds new metadata item to billing request object
+func (br *BillingRequest) AddMetadata(key, value string) {
+	if br.Metadata == nil {
+		br.Metadata = make(map[string]string)
+	}
+	br.Metadata[key] = value
+}
+
+// Action returns the action of the given type, nil when the billing request has none
+func (br *BillingRequest) Action(actionType string) *BillingRequestAction {
+	for _, a := range br.Actions {
+		if a.Type == actionType {
+			return a
+		}
+	}
+	return nil
+}
+
+// NextAction returns the first required action which is still pending and whose required
+// actions are all completed, i.e. the next step to take. It returns nil when no required
+// action is left, the billing request can then be fulfilled.
+func (br *BillingRequest) NextAction() *BillingRequestAction {
+	for _, a := range br.Actions {
+		if !a.Required || a.Status == BillingRequestActionStatusCompleted {
+			continue
+		}
+		ready := true
+		for _, t := range a.RequiresActions {
+			if r := br.Action(t); r != nil && r.Status != BillingRequestActionStatusCompleted {
+				ready = false
+				break
+			}
+		}
+		if ready {
+			return a
+		}
+	}
+	return nil
+}
+
+func (p *BillingRequestListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+
+	setValue(v, "customer", p.Customer)
+	setValue(v, "status", p.Status)
+	return v, nil
+}
+
+// CreateBillingRequest creates a new billing request object.
+//
+// Relative endpoint: POST /billing_requests
+func (c *Client) CreateBillingRequest(billingRequest *BillingRequest) error {
+	return c.CreateBillingRequestContext(context.Background(), billingRequest)
+}
+
+// CreateBillingRequestContext is the same as CreateBillingRequest, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateBillingRequestContext(ctx context.Context, billingRequest *BillingRequest, opts ...RequestOption) error {
+	billingRequestReq := &billingRequestWrapper{billingRequest}
+
+	err := c.create(ctx, billingRequestEndpoint, billingRequestReq, billingRequestReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetBillingRequests returns a cursor-paginated list of your billing requests.
+//
+// Relative endpoint: GET /billing_requests
+func (c *Client) GetBillingRequests(params *BillingRequestListParams) (*BillingRequestListResponse, error) {
+	return c.GetBillingRequestsContext(context.Background(), params)
+}
+
+// GetBillingRequestsContext is the same as GetBillingRequests, but uses ctx for the request and applies opts to it.
+func (c *Client) GetBillingRequestsContext(ctx context.Context, params *BillingRequestListParams, opts ...RequestOption) (*BillingRequestListResponse, error) {
+	if params == nil {
+		params = &BillingRequestListParams{}
+	}
+	path, err := listPath(billingRequestEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &BillingRequestListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateBillingRequests returns an iterator over all your billing requests, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateBillingRequests(params *BillingRequestListParams) *BillingRequestIterator {
+	return c.IterateBillingRequestsContext(context.Background(), params)
+}
+
+// IterateBillingRequestsContext is the same as IterateBillingRequests, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateBillingRequestsContext(ctx context.Context, params *BillingRequestListParams, opts ...RequestOption) *BillingRequestIterator {
+	if params == nil {
+		params = &BillingRequestListParams{}
+	}
+	p := *params
+
+	return &BillingRequestIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetBillingRequestsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.BillingRequests))
+		for i, v := range list.BillingRequests {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// BillingRequest returns the billing request the iterator advanced to
+func (it *BillingRequestIterator) BillingRequest() *BillingRequest {
+	v, _ := it.Current().(*BillingRequest)
+	return v
+}
+
+// GetBillingRequest retrieves the details of an existing billing request.
+//
+// Relative endpoint: GET /billing_requests/BRQ123
+func (c *Client) GetBillingRequest(id string) (*BillingRequest, error) {
+	return c.GetBillingRequestContext(context.Background(), id)
+}
+
+// GetBillingRequestContext is the same as GetBillingRequest, but uses ctx for the request and applies opts to it.
+func (c *Client) GetBillingRequestContext(ctx context.Context, id string, opts ...RequestOption) (*BillingRequest, error) {
+	wrapper := &billingRequestWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, billingRequestEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.BillingRequest, err
+}
+
+// billingRequestAction posts the action of a billing request, data is sent as the data of the action when not nil
+func (c *Client) billingRequestAction(ctx context.Context, id, action string, data interface{}, opts ...RequestOption) (*BillingRequest, error) {
+	var body interface{}
+	if data != nil {
+		body = map[string]interface{}{
+			"data": data,
+		}
+	}
+
+	wrapper := &billingRequestWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/%s`, billingRequestEndpoint, id, action), body, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.BillingRequest, err
+}
+
+// CollectBillingRequestCustomerDetails completes the collect_customer_details action with
+// the contact details and address of the payer.
+//
+// Relative endpoint: POST /billing_requests/BRQ123/actions/collect_customer_details
+func (c *Client) CollectBillingRequestCustomerDetails(id string, details *BillingRequestCustomerDetails) (*BillingRequest, error) {
+	return c.CollectBillingRequestCustomerDetailsContext(context.Background(), id, details)
+}
+
+// CollectBillingRequestCustomerDetailsContext is the same as CollectBillingRequestCustomerDetails, but uses ctx for the request and applies opts to it.
+func (c *Client) CollectBillingRequestCustomerDetailsContext(ctx context.Context, id string, details *BillingRequestCustomerDetails, opts ...RequestOption) (*BillingRequest, error) {
+	if details == nil {
+		return nil, fmt.Errorf("%w: customer details are required", ErrInvalidParams)
+	}
+	return c.billingRequestAction(ctx, id, "collect_customer_details", details, opts...)
+}
+
+// CollectBillingRequestBankAccount completes the collect_bank_account action with the
+// bank details of the payer.
+//
+// Relative endpoint: POST /billing_requests/BRQ123/actions/collect_bank_account
+func (c *Client) CollectBillingRequestBankAccount(id string, bankAccount *BillingRequestBankAccount) (*BillingRequest, error) {
+	return c.CollectBillingRequestBankAccountContext(context.Background(), id, bankAccount)
+}
+
+// CollectBillingRequestBankAccountContext is the same as CollectBillingRequestBankAccount, but uses ctx for the request and applies opts to it.
+func (c *Client) CollectBillingRequestBankAccountContext(ctx context.Context, id string, bankAccount *BillingRequestBankAccount, opts ...RequestOption) (*BillingRequest, error) {
+	if bankAccount == nil {
+		return nil, fmt.Errorf("%w: bank account details are required", ErrInvalidParams)
+	}
+	return c.billingRequestAction(ctx, id, "collect_bank_account", bankAccount, opts...)
+}
+
+// ConfirmBillingRequestPayerDetails completes the confirm_payer_details action, once the
+// payer has confirmed the collected details.
+//
+// Relative endpoint: POST /billing_requests/BRQ123/actions/confirm_payer_details
+func (c *Client) ConfirmBillingRequestPayerDetails(id string) (*BillingRequest, error) {
+	return c.ConfirmBillingRequestPayerDetailsContext(context.Background(), id)
+}
+
+// ConfirmBillingRequestPayerDetailsContext is the same as ConfirmBillingRequestPayerDetails, but uses ctx for the request and applies opts to it.
+func (c *Client) ConfirmBillingRequestPayerDetailsContext(ctx context.Context, id string, opts ...RequestOption) (*BillingRequest, error) {
+	return c.billingRequestAction(ctx, id, "confirm_payer_details", nil, opts...)
+}
+
+// FulfilBillingRequest fulfils a billing request whose required actions are all completed,
+// creating the mandate and payment it requests.
+//
+// Relative endpoint: POST /billing_requests/BRQ123/actions/fulfil
+func (c *Client) FulfilBillingRequest(id string) (*BillingRequest, error) {
+	return c.FulfilBillingRequestContext(context.Background(), id)
+}
+
+// FulfilBillingRequestContext is the same as FulfilBillingRequest, but uses ctx for the request and applies opts to it.
+func (c *Client) FulfilBillingRequestContext(ctx context.Context, id string, opts ...RequestOption) (*BillingRequest, error) {
+	return c.billingRequestAction(ctx, id, "fulfil", nil, opts...)
+}
+
+// CancelBillingRequest immediately cancels a billing request, causing all billing request
+// flows to expire.
+//
+// Relative endpoint: POST /billing_requests/BRQ123/actions/cancel
+func (c *Client) CancelBillingRequest(id string) (*BillingRequest, error) {
+	return c.CancelBillingRequestContext(context.Background(), id)
+}
+
+// CancelBillingRequestContext is the same as CancelBillingRequest, but uses ctx for the request and applies opts to it.
+func (c *Client) CancelBillingRequestContext(ctx context.Context, id string, opts ...RequestOption) (*BillingRequest, error) {
+	return c.billingRequestAction(ctx, id, "cancel", nil, opts...)
+}
+
+// NotifyBillingRequest notifies the customer linked to the billing request, asking them to
+// authorise it. Only "email" is supported as notificationType, redirectURI is where the
+// customer is sent once done.
+//
+// Relative endpoint: POST /billing_requests/BRQ123/actions/notify
+func (c *Client) NotifyBillingRequest(id, notificationType, redirectURI string) (*BillingRequest, error) {
+	return c.NotifyBillingRequestContext(context.Background(), id, notificationType, redirectURI)
+}
+
+// NotifyBillingRequestContext is the same as NotifyBillingRequest, but uses ctx for the request and applies opts to it.
+func (c *Client) NotifyBillingRequestContext(ctx context.Context, id, notificationType, redirectURI string, opts ...RequestOption) (*BillingRequest, error) {
+	data := map[string]interface{}{
+		"notification_type": notificationType,
+	}
+	if redirectURI != "" {
+		data["redirect_uri"] = redirectURI
+	}
+	return c.billingRequestAction(ctx, id, "notify", data, opts...)
+}
+
+// FallbackBillingRequest falls back from bank authorisation to a direct debit mandate,
+// for billing requests created with FallbackEnabled.
+//
+// Relative endpoint: POST /billing_requests/BRQ123/actions/fallback
+func (c *Client) FallbackBillingRequest(id string) (*BillingRequest, error) {
+	return c.FallbackBillingRequestContext(context.Background(), id)
+}
+
+// FallbackBillingRequestContext is the same as FallbackBillingRequest, but uses ctx for the request and applies opts to it.
+func (c *Client) FallbackBillingRequestContext(ctx context.Context, id string, opts ...RequestOption) (*BillingRequest, error) {
+	return c.billingRequestAction(ctx, id, "fallback", nil, opts...)
+}
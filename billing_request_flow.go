@@ -0,0 +1,113 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	billingRequestFlowEndpoint = "billing_request_flows"
+)
+
+type (
+	// BillingRequestFlow Billing Request Flows can be created to enable a payer to authorise a
+	// billing request on the GoCardless hosted pages. Send the payer to AuthorisationURL, they are
+	// sent back to RedirectURI once done, or to ExitURI when they leave the flow.
+	BillingRequestFlow struct {
+		// ID is a unique identifier, beginning with "BRF".
+		ID string `json:"id,omitempty"`
+		// AuthorisationURL URL for a GC-controlled flow which will allow the payer to fulfil the billing request
+		AuthorisationURL string `json:"authorisation_url,omitempty"`
+		// AutoFulfil fulfil the billing request on completion of the flow, true by default
+		AutoFulfil *bool `json:"auto_fulfil,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the flow was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// ExitURI URL that the payer can be taken to if there isn’t a way to progress ahead in flow
+		ExitURI string `json:"exit_uri,omitempty"`
+		// ExpiresAt the time the flow expires, it can no longer be used after
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+		// Language ISO 639-1 code of the language of the hosted pages, e.g. "fr"
+		Language string `json:"language,omitempty"`
+		// LockBankAccount the payer cannot change the bank account collected with the billing request
+		LockBankAccount bool `json:"lock_bank_account,omitempty"`
+		// LockCurrency the payer cannot change the currency of the billing request
+		LockCurrency bool `json:"lock_currency,omitempty"`
+		// LockCustomerDetails the payer cannot change the customer details collected with the billing request
+		LockCustomerDetails bool `json:"lock_customer_details,omitempty"`
+		// PrefilledCustomer customer details used to pre-fill the hosted pages, only used on creation
+		PrefilledCustomer *PrefilledCustomer `json:"prefilled_customer,omitempty"`
+		// RedirectURI URL that the payer can be redirected to after completing the request flow
+		RedirectURI string `json:"redirect_uri,omitempty"`
+		// SessionToken session token populated when responding to the initialise action
+		SessionToken string `json:"session_token,omitempty"`
+		// ShowRedirectButtons show the buttons back to RedirectURI and ExitURI on the hosted pages
+		ShowRedirectButtons bool `json:"show_redirect_buttons,omitempty"`
+		// ShowSuccessRedirectButton show the button back to RedirectURI on the success page
+		ShowSuccessRedirectButton bool `json:"show_success_redirect_button,omitempty"`
+		// SkipSuccessScreen send the payer to RedirectURI without showing the success page
+		SkipSuccessScreen bool `json:"skip_success_screen,omitempty"`
+		// Links links to the billing request the flow is for
+		Links billingRequestFlowLinks `json:"links"`
+	}
+	billingRequestFlowLinks struct {
+		BillingRequestID string `json:"billing_request"`
+	}
+
+	// billingRequestFlowWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	billingRequestFlowWrapper struct {
+		BillingRequestFlow *BillingRequestFlow `json:"billing_request_flows"`
+	}
+)
+
+func (f *BillingRequestFlow) String() string {
+	bs, _ := json.Marshal(f)
+	return string(bs)
+}
+
+// NewBillingRequestFlow instantiate a new billing request flow object for a billing request
+func NewBillingRequestFlow(billingRequestID, redirectURI, exitURI string) *BillingRequestFlow {
+	return &BillingRequestFlow{
+		RedirectURI: redirectURI,
+		ExitURI:     exitURI,
+		Links:       billingRequestFlowLinks{BillingRequestID: billingRequestID},
+	}
+}
+
+// CreateBillingRequestFlow creates a new billing request flow object, send the payer to its AuthorisationURL.
+//
+// Relative endpoint: POST /billing_request_flows
+func (c *Client) CreateBillingRequestFlow(flow *BillingRequestFlow) error {
+	return c.CreateBillingRequestFlowContext(context.Background(), flow)
+}
+
+// CreateBillingRequestFlowContext is the same as CreateBillingRequestFlow, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateBillingRequestFlowContext(ctx context.Context, flow *BillingRequestFlow, opts ...RequestOption) error {
+	flowReq := &billingRequestFlowWrapper{flow}
+
+	err := c.post(ctx, billingRequestFlowEndpoint, flowReq, flowReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// InitialiseBillingRequestFlow returns the flow with a fresh SessionToken, which can be
+// used to power integrations that manipulate the flow.
+//
+// Relative endpoint: POST /billing_request_flows/BRF123/actions/initialise
+func (c *Client) InitialiseBillingRequestFlow(id string) (*BillingRequestFlow, error) {
+	return c.InitialiseBillingRequestFlowContext(context.Background(), id)
+}
+
+// InitialiseBillingRequestFlowContext is the same as InitialiseBillingRequestFlow, but uses ctx for the request and applies opts to it.
+func (c *Client) InitialiseBillingRequestFlowContext(ctx context.Context, id string, opts ...RequestOption) (*BillingRequestFlow, error) {
+	wrapper := &billingRequestFlowWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/initialise`, billingRequestFlowEndpoint, id), nil, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.BillingRequestFlow, err
+}
@@ -0,0 +1,231 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	billingRequestTemplateEndpoint = "billing_request_templates"
+)
+
+type (
+	// BillingRequestTemplate Billing Request Templates are reusable templates that result in
+	// numerous billing requests with similar attributes. Each payer visiting AuthorisationURL,
+	// a payment link which can be shared, gets a billing request created from the template.
+	BillingRequestTemplate struct {
+		// ID is a unique identifier, beginning with "BRT".
+		ID string `json:"id,omitempty"`
+		// AuthorisationURL Permanent URL that customers can visit to allow them to complete a flow
+		// based on this template, before being returned to the RedirectURI
+		AuthorisationURL string `json:"authorisation_url,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the template was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// MandateRequestCurrency currency code of the mandates, set to request a mandate
+		MandateRequestCurrency string `json:"mandate_request_currency,omitempty"`
+		// MandateRequestDescription A human-readable description of the mandates, shown to the payers
+		MandateRequestDescription string `json:"mandate_request_description,omitempty"`
+		// MandateRequestMetadata is a key-value store of custom data, copied to the mandates
+		MandateRequestMetadata map[string]string `json:"mandate_request_metadata,omitempty"`
+		// MandateRequestScheme Direct Debit scheme of the mandates
+		MandateRequestScheme string `json:"mandate_request_scheme,omitempty"`
+		// MandateRequestVerify how the payers' bank accounts are verified, see BillingRequestMandateRequest.Verify
+		MandateRequestVerify string `json:"mandate_request_verify,omitempty"`
+		// Metadata is a key-value store of custom data. Up to 3 keys are permitted, with key names up to 50
+		// characters and values up to 500 characters.
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// Name of the template, shown in the dashboard
+		Name string `json:"name,omitempty"`
+		// PaymentRequestAmount Amount in full currency units as a decimal string, e.g. "69.99",
+		// set to request a payment
+		PaymentRequestAmount string `json:"payment_request_amount,omitempty"`
+		// PaymentRequestCurrency currency code of the payments, set to request a payment
+		PaymentRequestCurrency string `json:"payment_request_currency,omitempty"`
+		// PaymentRequestDescription A human-readable description of the payments, shown to the payers
+		PaymentRequestDescription string `json:"payment_request_description,omitempty"`
+		// PaymentRequestMetadata is a key-value store of custom data, copied to the payments
+		PaymentRequestMetadata map[string]string `json:"payment_request_metadata,omitempty"`
+		// PaymentRequestScheme the scheme of the payments, e.g. "faster_payments"
+		PaymentRequestScheme string `json:"payment_request_scheme,omitempty"`
+		// RedirectURI URL that the payers are redirected to after completing the flow
+		RedirectURI string `json:"redirect_uri,omitempty"`
+		// UpdatedAt is a timestamp recording when the template was last updated
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+
+	// billingRequestTemplateWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	billingRequestTemplateWrapper struct {
+		BillingRequestTemplate *BillingRequestTemplate `json:"billing_request_templates"`
+	}
+
+	// BillingRequestTemplateListParams parameters for listing billing request templates, nil lists the first page
+	BillingRequestTemplateListParams struct {
+		ListParams
+	}
+
+	// BillingRequestTemplateListResponse a List response of BillingRequestTemplate instances
+	BillingRequestTemplateListResponse struct {
+		BillingRequestTemplates []*BillingRequestTemplate `json:"billing_request_templates"`
+		Meta                    Meta                      `json:"meta,omitempty"`
+	}
+
+	// BillingRequestTemplateIterator iterates over billing request templates, see Client.IterateBillingRequestTemplates
+	BillingRequestTemplateIterator struct {
+		*Iter
+	}
+)
+
+func (t *BillingRequestTemplate) String() string {
+	bs, _ := json.Marshal(t)
+	return string(bs)
+}
+
+// NewBillingRequestTemplate instantiate a new billing request template object, set its
+// mandate request and/or payment request fields before creating it
+func NewBillingRequestTemplate(name, redirectURI string) *BillingRequestTemplate {
+	return &BillingRequestTemplate{
+		Name:        name,
+		RedirectURI: redirectURI,
+	}
+}
+
+// AddMetadata adds new metadata item to billing request template object
+func (t *BillingRequestTemplate) AddMetadata(key, value string) {
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]string)
+	}
+	t.Metadata[key] = value
+}
+
+func (p *BillingRequestTemplateListParams) values() (url.Values, error) {
+	return p.ListParams.values()
+}
+
+// CreateBillingRequestTemplate creates a new billing request template object, share its
+// AuthorisationURL with the payers.
+//
+// Relative endpoint: POST /billing_request_templates
+func (c *Client) CreateBillingRequestTemplate(template *BillingRequestTemplate) error {
+	return c.CreateBillingRequestTemplateContext(context.Background(), template)
+}
+
+// CreateBillingRequestTemplateContext is the same as CreateBillingRequestTemplate, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateBillingRequestTemplateContext(ctx context.Context, template *BillingRequestTemplate, opts ...RequestOption) error {
+	templateReq := &billingRequestTemplateWrapper{template}
+
+	err := c.create(ctx, billingRequestTemplateEndpoint, templateReq, templateReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetBillingRequestTemplates returns a cursor-paginated list of your billing request templates.
+//
+// Relative endpoint: GET /billing_request_templates
+func (c *Client) GetBillingRequestTemplates(params *BillingRequestTemplateListParams) (*BillingRequestTemplateListResponse, error) {
+	return c.GetBillingRequestTemplatesContext(context.Background(), params)
+}
+
+// GetBillingRequestTemplatesContext is the same as GetBillingRequestTemplates, but uses ctx for the request and applies opts to it.
+func (c *Client) GetBillingRequestTemplatesContext(ctx context.Context, params *BillingRequestTemplateListParams, opts ...RequestOption) (*BillingRequestTemplateListResponse, error) {
+	if params == nil {
+		params = &BillingRequestTemplateListParams{}
+	}
+	path, err := listPath(billingRequestTemplateEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &BillingRequestTemplateListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateBillingRequestTemplates returns an iterator over all your billing request templates,
+// fetching pages lazily as it advances. params sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateBillingRequestTemplates(params *BillingRequestTemplateListParams) *BillingRequestTemplateIterator {
+	return c.IterateBillingRequestTemplatesContext(context.Background(), params)
+}
+
+// IterateBillingRequestTemplatesContext is the same as IterateBillingRequestTemplates, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateBillingRequestTemplatesContext(ctx context.Context, params *BillingRequestTemplateListParams, opts ...RequestOption) *BillingRequestTemplateIterator {
+	if params == nil {
+		params = &BillingRequestTemplateListParams{}
+	}
+	p := *params
+
+	return &BillingRequestTemplateIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetBillingRequestTemplatesContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.BillingRequestTemplates))
+		for i, v := range list.BillingRequestTemplates {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// BillingRequestTemplate returns the billing request template the iterator advanced to
+func (it *BillingRequestTemplateIterator) BillingRequestTemplate() *BillingRequestTemplate {
+	v, _ := it.Current().(*BillingRequestTemplate)
+	return v
+}
+
+// GetBillingRequestTemplate retrieves the details of an existing billing request template.
+//
+// Relative endpoint: GET /billing_request_templates/BRT123
+func (c *Client) GetBillingRequestTemplate(id string) (*BillingRequestTemplate, error) {
+	return c.GetBillingRequestTemplateContext(context.Background(), id)
+}
+
+// GetBillingRequestTemplateContext is the same as GetBillingRequestTemplate, but uses ctx for the request and applies opts to it.
+func (c *Client) GetBillingRequestTemplateContext(ctx context.Context, id string, opts ...RequestOption) (*BillingRequestTemplate, error) {
+	wrapper := &billingRequestTemplateWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, billingRequestTemplateEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.BillingRequestTemplate, err
+}
+
+// UpdateBillingRequestTemplate Updates a billing request template object. The billing requests
+// already created from the template are not changed.
+//
+// Relative endpoint: PUT /billing_request_templates/BRT123
+func (c *Client) UpdateBillingRequestTemplate(template *BillingRequestTemplate) error {
+	return c.UpdateBillingRequestTemplateContext(context.Background(), template)
+}
+
+// UpdateBillingRequestTemplateContext is the same as UpdateBillingRequestTemplate, but uses ctx for the request and applies opts to it.
+func (c *Client) UpdateBillingRequestTemplateContext(ctx context.Context, template *BillingRequestTemplate, opts ...RequestOption) error {
+	// read-only fields are left out by the copy
+	update := *template
+	update.ID = ""
+	update.AuthorisationURL = ""
+	update.CreatedAt = nil
+	update.UpdatedAt = nil
+	templateMeta := &billingRequestTemplateWrapper{&update}
+
+	templateRes := &billingRequestTemplateWrapper{template}
+
+	err := c.put(ctx, fmt.Sprintf(`%s/%s`, billingRequestTemplateEndpoint, template.ID), templateMeta, templateRes, opts...)
+	if err != nil {
+		return err
+	}
+	return err
+}
@@ -0,0 +1,24 @@
+package gocardless
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBillingRequestTemplatePaymentRequestAmount(t *testing.T) {
+	template := &BillingRequestTemplate{}
+	if err := json.Unmarshal([]byte(`{"id":"BRT123","payment_request_amount":"69.99"}`), template); err != nil {
+		t.Fatal(err)
+	}
+	if template.PaymentRequestAmount != "69.99" {
+		t.Errorf("expected 69.99, got %q", template.PaymentRequestAmount)
+	}
+
+	bs, err := json.Marshal(NewBillingRequestTemplate("Gold plan", "https://example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name":"Gold plan","redirect_uri":"https://example.com"}`; string(bs) != want {
+		t.Errorf("expected %s, got %s", want, bs)
+	}
+}
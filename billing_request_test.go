@@ -0,0 +1,52 @@
+package gocardless
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestBillingRequestActionBody(t *testing.T) {
+	var body string
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body = r.URL.Path + " " + string(b)
+		w.Write([]byte(`{"billing_requests":{"id":"BRQ123"}}`))
+	})
+	defer srv.Close()
+
+	details := &BillingRequestBankAccount{AccountHolderName: "Frank Osborne", IBAN: "GB60BARC20000055779911"}
+	if _, err := c.CollectBillingRequestBankAccount("BRQ123", details); err != nil {
+		t.Fatal(err)
+	}
+	want := `/billing_requests/BRQ123/actions/collect_bank_account {"data":{"account_holder_name":"Frank Osborne","iban":"GB60BARC20000055779911"}}`
+	if body != want {
+		t.Errorf("expected %s, got %s", want, body)
+	}
+
+	if _, err := c.FulfilBillingRequest("BRQ123"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "/billing_requests/BRQ123/actions/fulfil "; body != want {
+		t.Errorf("expected %q, got %q", want, body)
+	}
+}
+
+func TestBillingRequestActionNilDetails(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	defer srv.Close()
+
+	if _, err := c.CollectBillingRequestCustomerDetails("BRQ123", nil); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("expected ErrInvalidParams, got %v", err)
+	}
+	if _, err := c.CollectBillingRequestBankAccount("BRQ123", nil); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("expected ErrInvalidParams, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no request, got %d", calls)
+	}
+}
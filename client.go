@@ -2,13 +2,13 @@ package gocardless
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	uuid "github.com/satori/go.uuid"
 )
@@ -17,6 +17,10 @@ const (
 	apiVersion     = "2015-07-06"
 	baseLiveURL    = `https://api.gocardless.com/`
 	baseSandboxURL = `https://api-sandbox.gocardless.com/`
+	// defaultTimeout is the timeout of the http.Client created when WithHTTPClient is not used
+	defaultTimeout = 30 * time.Second
+	// defaultUserAgent is the User-Agent header sent when WithUserAgent is not used
+	defaultUserAgent = "gocardless-go"
 )
 
 // Client for interacting with the GoCardless Pro API
@@ -25,13 +29,29 @@ type Client struct {
 	AccessToken string
 	// RemoteURL is the address of the GoCardless API
 	RemoteURL string
+
+	httpClient  *http.Client
+	userAgent   string
+	headers     http.Header
+	retryPolicy RetryPolicy
+	limiter     *rateLimiter
+	// resolveConflicts fetches the already created resource on idempotent creation conflicts
+	resolveConflicts bool
+	// lookupBankDetails checks bank details before creating customer bank accounts
+	lookupBankDetails bool
+	middleware        []Middleware
 }
 
 // NewClient instantiate a client struct with your access token and environment, then
-// use the resource methods to access the API
-func NewClient(accessToken string, env Environment) *Client {
+// use the resource methods to access the API. Options are applied in order after the
+// environment has been resolved, so WithRemoteURL takes precedence over env.
+func NewClient(accessToken string, env Environment, opts ...ClientOption) (*Client, error) {
 	c := &Client{
 		AccessToken: accessToken,
+		httpClient:  &http.Client{Timeout: defaultTimeout},
+		userAgent:   defaultUserAgent,
+		headers:     make(http.Header),
+		limiter:     &rateLimiter{},
 	}
 
 	switch env {
@@ -40,84 +60,195 @@ func NewClient(accessToken string, env Environment) *Client {
 	case LiveEnvironment:
 		c.RemoteURL = baseLiveURL
 	default:
-		log.Fatalf("Invalid environment %s, use one of (%s, %s)", env, SandboxEnvironment, LiveEnvironment)
+		return nil, InvalidEnvironment(fmt.Errorf("invalid environment %s, use one of (%s, %s)", env, SandboxEnvironment, LiveEnvironment))
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
 	}
-	return c
+	return c, nil
 }
 
-func (c *Client) makeRequest(path, method string, body, dst interface{}) error {
-	req, err := c.newRequest(path, method, body)
-	if err != nil {
-		return err
+func (c *Client) makeRequest(ctx context.Context, path, method string, body, dst interface{}, opts ...RequestOption) error {
+	if strings.ToUpper(method) == http.MethodPatch {
+		return errors.New(InvalidMethodError)
 	}
+	o := newRequestOptions(opts)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	var bs []byte
+	if body != nil {
+		var err error
+		bs, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
 
-	if err != nil {
-		return err
+	var idempotencyKey string
+	if method == http.MethodPost {
+		// Add Idempotency header key when creating a resouce, the same key is
+		// sent on every retry so that the resource is only created once
+		// https://developer.gocardless.com/api-reference/#making-requests-idempotency-keys
+		idempotencyKey = o.idempotencyKey
+		if idempotencyKey == "" {
+			u, _ := uuid.NewV4()
+			idempotencyKey = u.String()
+		}
 	}
 
-	switch resp.StatusCode {
-	case http.StatusTooManyRequests:
-		return errors.New("StatusTooManyRequests")
+	handler := c.handler()
+	var res *Response
+	var attempt int
+	for attempt = 1; ; attempt++ {
+		req := c.newRequest(path, method, bs, idempotencyKey, o.header, attempt)
+
+		if err := c.limiter.wait(ctx); err != nil {
+			return err
+		}
+		var err error
+		res, err = handler(ctx, req)
+		if res != nil {
+			c.limiter.update(res)
+		}
+		if attempt >= c.retryPolicy.MaxAttempts || !c.retryPolicy.shouldRetry(ctx, req, o.create, res, err) {
+			if err != nil {
+				if res != nil {
+					res.discard()
+				}
+				return err
+			}
+			if res == nil {
+				return errors.New("gocardless: middleware returned neither a response nor an error")
+			}
+			break
+		}
+
+		if res != nil {
+			res.discard()
+		}
+		if err := sleepContext(ctx, c.retryPolicy.backoff(attempt, res)); err != nil {
+			return err
+		}
 	}
 
-	res := newResponse(resp)
+	if o.response != nil {
+		*o.response = res.metadata()
+	}
 	// bind response to struct
-	return res.bind(dst)
+	err := res.bind(dst)
+	if err != nil && o.response != nil && o.response.RequestID == "" {
+		var apiErr *Error
+		if errors.As(err, &apiErr) {
+			o.response.RequestID = apiErr.RequestID
+		}
+	}
+	// a retried creation conflicts with its own earlier attempt when that one reached
+	// the API, so the conflict is always resolved after a retry
+	if err != nil && method == http.MethodPost && o.create && (c.resolveConflicts || attempt > 1) {
+		// the resource was created by an earlier request with the same
+		// idempotency key, return it instead of the conflict
+		if id := conflictingResourceID(err); id != "" {
+			return c.makeRequest(ctx, fmt.Sprintf(`%s/%s`, path, id), http.MethodGet, nil, dst, opts...)
+		}
+	}
+	return err
 }
 
-func (c *Client) newRequest(path, method string, body interface{}) (*http.Request, error) {
-	if strings.ToUpper(method) == http.MethodPatch {
-		return nil, errors.New(InvalidMethodError)
+// newRequest builds the Request of a single attempt, which is passed through the middleware chain
+func (c *Client) newRequest(path, method string, body []byte, idempotencyKey string, header http.Header, attempt int) *Request {
+	req := &Request{
+		Method:  method,
+		Path:    path,
+		Header:  make(http.Header),
+		Body:    body,
+		Attempt: attempt,
 	}
 
-	url := fmt.Sprintf("%s%s", c.RemoteURL, path)
+	// set default headers
+	c.setDefaultHeaders(req.Header)
 
-	var bs []byte
-	if body != nil {
-		bs, _ = json.Marshal(body)
+	for key, values := range header {
+		req.Header[key] = values
 	}
 
-	data := ioutil.NopCloser(bytes.NewBuffer(bs))
-	req, err := http.NewRequest(method, url, data)
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	return req
+}
+
+// handler returns the Client's middleware chain wrapped around send
+func (c *Client) handler() Handler {
+	h := Handler(c.send)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
+
+// send is the innermost Handler, sending req to the API
+func (c *Client) send(ctx context.Context, req *Request) (*Response, error) {
+	url := fmt.Sprintf("%s%s", c.RemoteURL, req.Path)
+
+	hreq, err := http.NewRequest(req.Method, url, bytes.NewReader(req.Body))
 	if err != nil {
 		return nil, err
 	}
+	hreq = hreq.WithContext(ctx)
+	hreq.Header = req.Header.Clone()
 
-	// set default headers
-	c.setDefaultHeaders(req)
+	resp, err := c.client().Do(hreq)
+	if err != nil {
+		return nil, &TransportError{Method: req.Method, URL: url, Err: err}
+	}
+	return newResponse(resp), nil
+}
 
-	if method == http.MethodPost {
-		// Add Idempotency header key when creating a resouce
-		// https://developer.gocardless.com/api-reference/#making-requests-idempotency-keys
-		u, _ := uuid.NewV4()
-		req.Header.Add("Idempotency-Key", u.String())
+// client returns the http.Client used to send requests, falling back to
+// http.DefaultClient for a Client that was not built with NewClient
+func (c *Client) client() *http.Client {
+	if c.httpClient == nil {
+		return http.DefaultClient
 	}
+	return c.httpClient
+}
 
-	return req, nil
+func (c *Client) setDefaultHeaders(header http.Header) {
+	for key, values := range c.headers {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+	header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken))
+	header.Set("GoCardless-Version", apiVersion)
+	header.Set("Accept", "application/json")
+	header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		header.Set("User-Agent", c.userAgent)
+	}
 }
 
-func (c *Client) setDefaultHeaders(req *http.Request) {
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken))
-	req.Header.Add("GoCardless-Version", apiVersion)
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Content-Type", "application/json")
+func (c *Client) get(ctx context.Context, path string, dst interface{}, opts ...RequestOption) error {
+	return c.makeRequest(ctx, path, http.MethodGet, nil, dst, opts...)
 }
 
-func (c *Client) get(path string, dst interface{}) error {
-	return c.makeRequest(path, http.MethodGet, nil, dst)
+func (c *Client) post(ctx context.Context, path string, body, dst interface{}, opts ...RequestOption) error {
+	return c.makeRequest(ctx, path, http.MethodPost, body, dst, opts...)
 }
 
-func (c *Client) post(path string, body, dst interface{}) error {
-	return c.makeRequest(path, http.MethodPost, body, dst)
+// create posts body to path to create a resource that can be retrieved at path/ID. Unlike other
+// POST requests it is retried, see RetryPolicy, and its conflicts resolved, see WithConflictResolution
+func (c *Client) create(ctx context.Context, path string, body, dst interface{}, opts ...RequestOption) error {
+	return c.post(ctx, path, body, dst, append([]RequestOption{asCreate()}, opts...)...)
 }
 
-func (c *Client) put(path string, body, dst interface{}) error {
-	return c.makeRequest(path, http.MethodPut, body, dst)
+func (c *Client) put(ctx context.Context, path string, body, dst interface{}, opts ...RequestOption) error {
+	return c.makeRequest(ctx, path, http.MethodPut, body, dst, opts...)
 }
 
-func (c *Client) delete(path string) error {
-	return c.makeRequest(path, http.MethodDelete, nil, nil)
+func (c *Client) delete(ctx context.Context, path string, opts ...RequestOption) error {
+	return c.makeRequest(ctx, path, http.MethodDelete, nil, nil, opts...)
 }
@@ -0,0 +1,166 @@
+package gocardless
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const conflictBody = `{"error":{"type":"invalid_state","code":409,"errors":[{"reason":"idempotent_creation_conflict","links":{"conflicting_resource_id":"PM123"}}]}}`
+
+// newTestClient returns a client sending its requests to a test server serving handler,
+// close the server once done
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) (*Client, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+
+	c, err := NewClient("token", SandboxEnvironment, append([]ClientOption{WithRemoteURL(srv.URL)}, opts...)...)
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+	return c, srv
+}
+
+func TestContextRequest(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/customers" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Idempotency-Key") == "" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		w.Write([]byte(`{"customers":{"id":"CU123","email":"user@example.com"}}`))
+	})
+	defer srv.Close()
+
+	customer := &Customer{Email: "user@example.com"}
+	if err := c.CreateCustomerContext(context.Background(), customer); err != nil {
+		t.Fatal(err)
+	}
+	if customer.ID != "CU123" {
+		t.Errorf("expected CU123, got %s", customer.ID)
+	}
+}
+
+func TestContextCancelled(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.GetCustomerContext(ctx, "CU123"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no request, got %d", calls)
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// hold the response until the client gives up
+		<-r.Context().Done()
+	})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetPaymentContext(ctx, "PM123"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestCreateConflictResolution(t *testing.T) {
+	var paths []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(conflictBody))
+			return
+		}
+		w.Write([]byte(`{"payments":{"id":"PM123","amount":100}}`))
+	}
+
+	c, srv := newTestClient(t, handler)
+	defer srv.Close()
+	payment := NewPayment(100, "GBP", "MD123")
+	err := c.CreatePaymentContext(context.Background(), payment, WithIdempotencyKey("key"))
+	if !errors.Is(err, ErrIdempotentCreationConflict) {
+		t.Fatalf("expected an idempotent creation conflict, got %v", err)
+	}
+
+	paths = nil
+	c, srv = newTestClient(t, handler, WithConflictResolution())
+	defer srv.Close()
+	err = c.CreatePaymentContext(context.Background(), payment, WithIdempotencyKey("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payment.ID != "PM123" {
+		t.Errorf("expected the conflicting payment, got %s", payment.ID)
+	}
+	if len(paths) != 2 || paths[1] != "GET /payments/PM123" {
+		t.Errorf("unexpected requests %v", paths)
+	}
+}
+
+func TestConflictResolutionOnlyForCreate(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(conflictBody))
+	}, WithConflictResolution())
+	defer srv.Close()
+
+	err := c.CancelPayment(&Payment{ID: "PM123"})
+	if !errors.Is(err, ErrIdempotentCreationConflict) {
+		t.Fatalf("expected an idempotent creation conflict, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+}
+
+func TestCreateConflictResolvedAfterRetry(t *testing.T) {
+	var keys []string
+	var paths []string
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"payments":{"id":"PM123","amount":100}}`))
+		case len(keys) == 0:
+			// the payment is created, but the response is lost
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(conflictBody))
+		}
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}))
+	defer srv.Close()
+
+	payment := NewPayment(100, "GBP", "MD123")
+	if err := c.CreatePayment(payment); err != nil {
+		t.Fatal(err)
+	}
+	if payment.ID != "PM123" {
+		t.Errorf("expected the payment created by the first attempt, got %s", payment.ID)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected the same idempotency key on both attempts, got %v", keys)
+	}
+	if len(paths) != 3 || paths[2] != "GET /payments/PM123" {
+		t.Errorf("unexpected requests %v", paths)
+	}
+}
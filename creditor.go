@@ -0,0 +1,273 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	creditorEndpoint = "creditors"
+)
+
+type (
+	// Creditor Each payment taken through the API is linked to a "creditor", to whom the payment
+	// is then paid out. In most cases your organisation will have a single creditor, but the API
+	// also supports collecting payments on behalf of others.
+	Creditor struct {
+		// ID is a unique identifier, beginning with "CR".
+		ID string `json:"id,omitempty"`
+		// AddressLine1 is the first line of the creditor’s address.
+		AddressLine1 string `json:"address_line1,omitempty"`
+		// AddressLine2 is the second line of the creditor’s address.
+		AddressLine2 string `json:"address_line2,omitempty"`
+		// AddressLine3 is the third line of the creditor’s address.
+		AddressLine3 string `json:"address_line3,omitempty"`
+		// City is the city of the creditor’s address.
+		City string `json:"city,omitempty"`
+		// CountryCode is the ISO 3166-1 alpha-2 code.
+		CountryCode string `json:"country_code,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the creditor was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// LogoURL URL for the creditor’s logo, which may be shown on their payment pages
+		LogoURL string `json:"logo_url,omitempty"`
+		// Name The creditor’s name
+		Name string `json:"name"`
+		// PostalCode is the creditor’s postal code
+		PostalCode string `json:"postal_code,omitempty"`
+		// Region is the creditor’s address region, county or department
+		Region string `json:"region,omitempty"`
+		// SchemeIdentifiers the identifiers the creditor collects payments under with each scheme
+		SchemeIdentifiers []*SchemeIdentifier `json:"scheme_identifiers,omitempty"`
+		// VerificationStatus whether the creditor needs to provide more information before
+		// it can receive payouts, one of "successful", "in_review" or "action_required"
+		VerificationStatus string `json:"verification_status,omitempty"`
+		// Links links to the default payout accounts of the creditor
+		Links creditorLinks `json:"links"`
+	}
+
+	// SchemeIdentifier the name and reference a creditor collects payments under with a scheme,
+	// shown to customers on their bank statements and notifications
+	SchemeIdentifier struct {
+		// Name The name which appears on customers’ bank statements
+		Name string `json:"name"`
+		// Scheme The scheme the identifier is for, e.g. "bacs" or "sepa_core"
+		Scheme string `json:"scheme"`
+		// Reference The scheme-unique identifier against which payments are submitted
+		Reference string `json:"reference"`
+		// Currency The currency of the scheme
+		Currency string `json:"currency,omitempty"`
+		// MinimumAdvanceNotice The minimum interval, in working days, between the sending of
+		// a pre-notification to the customer, and the charge date of a payment
+		MinimumAdvanceNotice int `json:"minimum_advance_notice,omitempty"`
+		// CanSpecifyMandateReference whether a custom reference can be submitted for mandates
+		CanSpecifyMandateReference bool `json:"can_specify_mandate_reference,omitempty"`
+		// Email address shown to customers for this scheme
+		Email string `json:"email,omitempty"`
+		// PhoneNumber shown to customers for this scheme
+		PhoneNumber string `json:"phone_number,omitempty"`
+	}
+
+	creditorLinks struct {
+		DefaultAUDPayoutAccount string `json:"default_aud_payout_account,omitempty"`
+		DefaultCADPayoutAccount string `json:"default_cad_payout_account,omitempty"`
+		DefaultDKKPayoutAccount string `json:"default_dkk_payout_account,omitempty"`
+		DefaultEURPayoutAccount string `json:"default_eur_payout_account,omitempty"`
+		DefaultGBPPayoutAccount string `json:"default_gbp_payout_account,omitempty"`
+		DefaultNZDPayoutAccount string `json:"default_nzd_payout_account,omitempty"`
+		DefaultSEKPayoutAccount string `json:"default_sek_payout_account,omitempty"`
+		DefaultUSDPayoutAccount string `json:"default_usd_payout_account,omitempty"`
+	}
+	// creditorWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	creditorWrapper struct {
+		Creditor *Creditor `json:"creditors"`
+	}
+
+	// CreditorListParams parameters for listing creditors, nil lists the first page
+	CreditorListParams struct {
+		ListParams
+		// CreatedAt limits to creditors created within the range
+		CreatedAt TimeRange
+	}
+
+	// CreditorListResponse a List response of Creditor instances
+	CreditorListResponse struct {
+		Creditors []*Creditor `json:"creditors"`
+		Meta      Meta        `json:"meta,omitempty"`
+	}
+
+	// CreditorIterator iterates over creditors, see Client.IterateCreditors
+	CreditorIterator struct {
+		*Iter
+	}
+)
+
+func (cr *Creditor) String() string {
+	bs, _ := json.Marshal(cr)
+	return string(bs)
+}
+
+// NewCreditor instantiate a new creditor object
+func NewCreditor(name, line1, city, postalCode, countryCode string) *Creditor {
+	return &Creditor{
+		Name:         name,
+		AddressLine1: line1,
+		City:         city,
+		PostalCode:   postalCode,
+		CountryCode:  countryCode,
+	}
+}
+
+func (p *CreditorListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// CreateCreditor creates a new creditor object. Only available to whitelabel partners in live.
+//
+// Relative endpoint: POST /creditors
+func (c *Client) CreateCreditor(creditor *Creditor) error {
+	return c.CreateCreditorContext(context.Background(), creditor)
+}
+
+// CreateCreditorContext is the same as CreateCreditor, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateCreditorContext(ctx context.Context, creditor *Creditor, opts ...RequestOption) error {
+	creditorReq := &creditorWrapper{creditor}
+
+	err := c.create(ctx, creditorEndpoint, creditorReq, creditorReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetCreditors returns a cursor-paginated list of your creditors.
+//
+// Relative endpoint: GET /creditors
+func (c *Client) GetCreditors(params *CreditorListParams) (*CreditorListResponse, error) {
+	return c.GetCreditorsContext(context.Background(), params)
+}
+
+// GetCreditorsContext is the same as GetCreditors, but uses ctx for the request and applies opts to it.
+func (c *Client) GetCreditorsContext(ctx context.Context, params *CreditorListParams, opts ...RequestOption) (*CreditorListResponse, error) {
+	if params == nil {
+		params = &CreditorListParams{}
+	}
+	path, err := listPath(creditorEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &CreditorListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateCreditors returns an iterator over all your creditors, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateCreditors(params *CreditorListParams) *CreditorIterator {
+	return c.IterateCreditorsContext(context.Background(), params)
+}
+
+// IterateCreditorsContext is the same as IterateCreditors, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateCreditorsContext(ctx context.Context, params *CreditorListParams, opts ...RequestOption) *CreditorIterator {
+	if params == nil {
+		params = &CreditorListParams{}
+	}
+	p := *params
+
+	return &CreditorIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetCreditorsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.Creditors))
+		for i, v := range list.Creditors {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// Creditor returns the creditor the iterator advanced to
+func (it *CreditorIterator) Creditor() *Creditor {
+	v, _ := it.Current().(*Creditor)
+	return v
+}
+
+// GetCreditor retrieves the details of an existing creditor.
+//
+// Relative endpoint: GET /creditors/CR123
+func (c *Client) GetCreditor(id string) (*Creditor, error) {
+	return c.GetCreditorContext(context.Background(), id)
+}
+
+// GetCreditorContext is the same as GetCreditor, but uses ctx for the request and applies opts to it.
+func (c *Client) GetCreditorContext(ctx context.Context, id string, opts ...RequestOption) (*Creditor, error) {
+	wrapper := &creditorWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, creditorEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Creditor, err
+}
+
+// UpdateCreditor Updates a creditor object. Supports the name, address and the default payout
+// accounts in Links, empty fields are left unchanged.
+//
+// Relative endpoint: PUT /creditors/CR123
+func (c *Client) UpdateCreditor(creditor *Creditor) error {
+	return c.UpdateCreditorContext(context.Background(), creditor)
+}
+
+// UpdateCreditorContext is the same as UpdateCreditor, but uses ctx for the request and applies opts to it.
+func (c *Client) UpdateCreditorContext(ctx context.Context, creditor *Creditor, opts ...RequestOption) error {
+	// remove unpermitted keys before update, empty fields are left unchanged
+	fields := map[string]interface{}{}
+	values := map[string]string{
+		"name":          creditor.Name,
+		"address_line1": creditor.AddressLine1,
+		"address_line2": creditor.AddressLine2,
+		"address_line3": creditor.AddressLine3,
+		"city":          creditor.City,
+		"region":        creditor.Region,
+		"postal_code":   creditor.PostalCode,
+		"country_code":  creditor.CountryCode,
+	}
+	for key, value := range values {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	if creditor.Links != (creditorLinks{}) {
+		fields["links"] = creditor.Links
+	}
+	creditorMeta := map[string]interface{}{
+		"creditors": fields,
+	}
+
+	creditorRes := &creditorWrapper{creditor}
+
+	err := c.put(ctx, fmt.Sprintf(`%s/%s`, creditorEndpoint, creditor.ID), creditorMeta, creditorRes, opts...)
+	if err != nil {
+		return err
+	}
+	return err
+}
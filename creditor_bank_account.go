@@ -0,0 +1,243 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	creditorBankAccountEndpoint = "creditor_bank_accounts"
+)
+
+type (
+	// CreditorBankAccount Creditor Bank Accounts hold the bank details of a creditor.
+	// These are the bank accounts which your payouts will be sent to.
+	CreditorBankAccount struct {
+		// ID is a unique identifier, beginning with "BA".
+		ID string `json:"id,omitempty"`
+		// AccountHolderName Name of the account holder, as known by the bank.
+		// This field will be transliterated, upcased and truncated to 18 characters.
+		AccountHolderName string `json:"account_holder_name"`
+		// AccountNumber Bank account number. Alternatively you can provide an iban
+		AccountNumber string `json:"account_number,omitempty"`
+		// AccountNumberEnding Last two digits of account number
+		AccountNumberEnding string `json:"account_number_ending,omitempty"`
+		// AccountType Bank account type, "savings" or "checking". Required for USD-denominated bank accounts
+		AccountType string `json:"account_type,omitempty"`
+		// BankCode Bank code
+		BankCode string `json:"bank_code,omitempty"`
+		// BankName Name of bank, taken from the bank details
+		BankName string `json:"bank_name,omitempty"`
+		// BranchCode Branch code
+		BranchCode string `json:"branch_code,omitempty"`
+		// CountryCode is the ISO 3166-1 alpha-2 code.
+		CountryCode string `json:"country_code,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the bank account was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Currency currency code, defaults to national currency of country_code
+		Currency string `json:"currency,omitempty"`
+		// Enabled indicates if bank account is disabled
+		Enabled bool `json:"enabled,omitempty"`
+		// IBAN International Bank Account Number
+		IBAN string `json:"iban,omitempty"`
+		// Metadata is a key-value store of custom data. Up to 3 keys are permitted, with key names up to 50
+		// characters and values up to 500 characters.
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// SetAsDefaultPayoutAccount Defaults to false. When this is set to true, it will cause this
+		// bank account to be set as the account that GoCardless will send payouts to. Only used on creation.
+		SetAsDefaultPayoutAccount bool `json:"set_as_default_payout_account,omitempty"`
+		// Links links to the creditor who owns the bank account
+		Links creditorBankAccountLinks `json:"links"`
+	}
+	creditorBankAccountLinks struct {
+		// CreditorID ID of the creditor who owns the bank account
+		CreditorID string `json:"creditor"`
+	}
+	// creditorBankAccountWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	creditorBankAccountWrapper struct {
+		CreditorBankAccount *CreditorBankAccount `json:"creditor_bank_accounts"`
+	}
+
+	// CreditorBankAccountListParams parameters for listing creditor bank accounts, nil lists the first page
+	CreditorBankAccountListParams struct {
+		ListParams
+		// CreatedAt limits to bank accounts created within the range
+		CreatedAt TimeRange
+		// Creditor ID of a creditor to filter bank accounts by
+		Creditor string
+		// Enabled when set, limits to enabled or disabled bank accounts
+		Enabled *bool
+	}
+
+	// CreditorBankAccountListResponse a List response of CreditorBankAccount instances
+	CreditorBankAccountListResponse struct {
+		CreditorBankAccounts []*CreditorBankAccount `json:"creditor_bank_accounts"`
+		Meta                 Meta                   `json:"meta,omitempty"`
+	}
+
+	// CreditorBankAccountIterator iterates over creditor bank accounts, see Client.IterateCreditorBankAccounts
+	CreditorBankAccountIterator struct {
+		*Iter
+	}
+)
+
+func (ca *CreditorBankAccount) String() string {
+	bs, _ := json.Marshal(ca)
+	return string(bs)
+}
+
+// NewCreditorBankAccount instantiate a new creditor bank account object
+func NewCreditorBankAccount(accountNumber, accountName, branchCode, countryCode, creditorID string) *CreditorBankAccount {
+	return &CreditorBankAccount{
+		AccountNumber:     accountNumber,
+		BranchCode:        branchCode,
+		AccountHolderName: accountName,
+		CountryCode:       countryCode,
+		Links:             creditorBankAccountLinks{CreditorID: creditorID},
+	}
+}
+
+// AddMetadata adds new metadata item to creditor bank account object
+func (ca *CreditorBankAccount) AddMetadata(key, value string) {
+	if ca.Metadata == nil {
+		ca.Metadata = make(map[string]string)
+	}
+	ca.Metadata[key] = value
+}
+
+func (p *CreditorBankAccountListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+
+	setValue(v, "creditor", p.Creditor)
+	if p.Enabled != nil {
+		v.Set("enabled", strconv.FormatBool(*p.Enabled))
+	}
+	return v, nil
+}
+
+// CreateCreditorBankAccount creates a new creditor bank account object. Set SetAsDefaultPayoutAccount
+// to have payouts in its currency sent to it.
+//
+// Relative endpoint: POST /creditor_bank_accounts
+func (c *Client) CreateCreditorBankAccount(cba *CreditorBankAccount) error {
+	return c.CreateCreditorBankAccountContext(context.Background(), cba)
+}
+
+// CreateCreditorBankAccountContext is the same as CreateCreditorBankAccount, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateCreditorBankAccountContext(ctx context.Context, cba *CreditorBankAccount, opts ...RequestOption) error {
+	cbaReq := &creditorBankAccountWrapper{cba}
+
+	err := c.create(ctx, creditorBankAccountEndpoint, cbaReq, cbaReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetCreditorBankAccounts returns a cursor-paginated list of your creditor bank accounts.
+//
+// Relative endpoint: GET /creditor_bank_accounts
+func (c *Client) GetCreditorBankAccounts(params *CreditorBankAccountListParams) (*CreditorBankAccountListResponse, error) {
+	return c.GetCreditorBankAccountsContext(context.Background(), params)
+}
+
+// GetCreditorBankAccountsContext is the same as GetCreditorBankAccounts, but uses ctx for the request and applies opts to it.
+func (c *Client) GetCreditorBankAccountsContext(ctx context.Context, params *CreditorBankAccountListParams, opts ...RequestOption) (*CreditorBankAccountListResponse, error) {
+	if params == nil {
+		params = &CreditorBankAccountListParams{}
+	}
+	path, err := listPath(creditorBankAccountEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &CreditorBankAccountListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateCreditorBankAccounts returns an iterator over all your creditor bank accounts, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateCreditorBankAccounts(params *CreditorBankAccountListParams) *CreditorBankAccountIterator {
+	return c.IterateCreditorBankAccountsContext(context.Background(), params)
+}
+
+// IterateCreditorBankAccountsContext is the same as IterateCreditorBankAccounts, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateCreditorBankAccountsContext(ctx context.Context, params *CreditorBankAccountListParams, opts ...RequestOption) *CreditorBankAccountIterator {
+	if params == nil {
+		params = &CreditorBankAccountListParams{}
+	}
+	p := *params
+
+	return &CreditorBankAccountIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetCreditorBankAccountsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.CreditorBankAccounts))
+		for i, v := range list.CreditorBankAccounts {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// CreditorBankAccount returns the creditor bank account the iterator advanced to
+func (it *CreditorBankAccountIterator) CreditorBankAccount() *CreditorBankAccount {
+	v, _ := it.Current().(*CreditorBankAccount)
+	return v
+}
+
+// GetCreditorBankAccount Retrieves the details of an existing creditor bank account.
+//
+// Relative endpoint: GET /creditor_bank_accounts/BA123
+func (c *Client) GetCreditorBankAccount(id string) (*CreditorBankAccount, error) {
+	return c.GetCreditorBankAccountContext(context.Background(), id)
+}
+
+// GetCreditorBankAccountContext is the same as GetCreditorBankAccount, but uses ctx for the request and applies opts to it.
+func (c *Client) GetCreditorBankAccountContext(ctx context.Context, id string, opts ...RequestOption) (*CreditorBankAccount, error) {
+	wrapper := &creditorBankAccountWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, creditorBankAccountEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.CreditorBankAccount, err
+}
+
+// DisableCreditorBankAccount disables a creditor bank account, it can no longer be used
+// for payouts. A disabled bank account cannot be re-enabled.
+//
+// Relative endpoint: POST /creditor_bank_accounts/BA123/actions/disable
+func (c *Client) DisableCreditorBankAccount(id string) (*CreditorBankAccount, error) {
+	return c.DisableCreditorBankAccountContext(context.Background(), id)
+}
+
+// DisableCreditorBankAccountContext is the same as DisableCreditorBankAccount, but uses ctx for the request and applies opts to it.
+func (c *Client) DisableCreditorBankAccountContext(ctx context.Context, id string, opts ...RequestOption) (*CreditorBankAccount, error) {
+	wrapper := &creditorBankAccountWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/disable`, creditorBankAccountEndpoint, id), nil, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.CreditorBankAccount, err
+}
@@ -0,0 +1,159 @@
+package gocardless
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCreateCreditor(t *testing.T) {
+	var body map[string]map[string]interface{}
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/creditors" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"creditors":{"id":"CR123","name":"Acme","verification_status":"in_review","scheme_identifiers":[{"name":"Acme","scheme":"bacs","reference":"420042"}]}}`))
+	})
+	defer srv.Close()
+
+	creditor := NewCreditor("Acme", "1 Main Street", "London", "E1 1AA", "GB")
+	if err := c.CreateCreditor(creditor); err != nil {
+		t.Fatal(err)
+	}
+	if body["creditors"]["name"] != "Acme" || body["creditors"]["postal_code"] != "E1 1AA" {
+		t.Errorf("unexpected body %v", body)
+	}
+	if creditor.ID != "CR123" || creditor.VerificationStatus != "in_review" || len(creditor.SchemeIdentifiers) != 1 {
+		t.Errorf("unexpected creditor %v", creditor)
+	}
+}
+
+func TestUpdateCreditorBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		creditor *Creditor
+		want     map[string]interface{}
+	}{
+		{
+			name:     "name only",
+			creditor: &Creditor{ID: "CR123", Name: "Acme Ltd", VerificationStatus: "successful"},
+			want:     map[string]interface{}{"name": "Acme Ltd"},
+		},
+		{
+			name: "address and links",
+			creditor: &Creditor{
+				ID:           "CR123",
+				AddressLine1: "2 High Street",
+				City:         "Leeds",
+				Links:        creditorLinks{DefaultGBPPayoutAccount: "BA123"},
+			},
+			want: map[string]interface{}{
+				"address_line1": "2 High Street",
+				"city":          "Leeds",
+				"links":         map[string]interface{}{"default_gbp_payout_account": "BA123"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]map[string]interface{}
+			c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut || r.URL.Path != "/creditors/CR123" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				w.Write([]byte(`{"creditors":{"id":"CR123","name":"Acme Ltd"}}`))
+			})
+			defer srv.Close()
+
+			if err := c.UpdateCreditor(tt.creditor); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(body["creditors"], tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, body["creditors"])
+			}
+		})
+	}
+}
+
+func TestGetCreditors(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/creditors" || r.URL.RawQuery != "limit=10" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"creditors":[{"id":"CR123","links":{"default_eur_payout_account":"BA456"}}],"meta":{"cursors":{},"limit":10}}`))
+	})
+	defer srv.Close()
+
+	res, err := c.GetCreditors(&CreditorListParams{ListParams: ListParams{Limit: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Creditors) != 1 || res.Creditors[0].Links.DefaultEURPayoutAccount != "BA456" {
+		t.Errorf("unexpected creditors %v", res.Creditors)
+	}
+}
+
+func TestCreditorBankAccounts(t *testing.T) {
+	var requests []string
+	var body map[string]map[string]interface{}
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.String())
+		switch r.URL.Path {
+		case "/creditor_bank_accounts":
+			if r.Method == http.MethodPost {
+				json.NewDecoder(r.Body).Decode(&body)
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"creditor_bank_accounts":{"id":"BA123","enabled":true,"links":{"creditor":"CR123"}}}`))
+				return
+			}
+			w.Write([]byte(`{"creditor_bank_accounts":[{"id":"BA123","enabled":true}],"meta":{"cursors":{},"limit":50}}`))
+		case "/creditor_bank_accounts/BA123/actions/disable":
+			w.Write([]byte(`{"creditor_bank_accounts":{"id":"BA123","enabled":false}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer srv.Close()
+
+	account := NewCreditorBankAccount("55779911", "Acme", "200000", "GB", "CR123")
+	account.SetAsDefaultPayoutAccount = true
+	if err := c.CreateCreditorBankAccount(account); err != nil {
+		t.Fatal(err)
+	}
+	if account.ID != "BA123" || !account.Enabled {
+		t.Errorf("unexpected account %v", account)
+	}
+	if body["creditor_bank_accounts"]["set_as_default_payout_account"] != true {
+		t.Errorf("expected set_as_default_payout_account to be sent, got %v", body)
+	}
+
+	enabled := true
+	list, err := c.GetCreditorBankAccounts(&CreditorBankAccountListParams{Creditor: "CR123", Enabled: &enabled})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.CreditorBankAccounts) != 1 {
+		t.Errorf("expected 1 account, got %d", len(list.CreditorBankAccounts))
+	}
+
+	disabled, err := c.DisableCreditorBankAccount("BA123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if disabled.Enabled {
+		t.Errorf("expected the account to be disabled, got %v", disabled)
+	}
+
+	want := []string{
+		"POST /creditor_bank_accounts",
+		"GET /creditor_bank_accounts?creditor=CR123&enabled=true",
+		"POST /creditor_bank_accounts/BA123/actions/disable",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("expected %q, got %q", want, requests)
+	}
+}
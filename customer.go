@@ -1,8 +1,10 @@
 package gocardless
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -58,11 +60,25 @@ type (
 		Customer *Customer `json:"customers"`
 	}
 
+	// CustomerListParams parameters for listing customers, nil lists the first page
+	CustomerListParams struct {
+		ListParams
+		// CreatedAt limits to customers created within the range
+		CreatedAt TimeRange
+		// Currency ISO 4217 currency code, limits to customers with a bank account in that currency
+		Currency string
+	}
+
 	// CustomerListResponse a List response of Customer instances
 	CustomerListResponse struct {
 		Customers []*Customer `json:"customers"`
 		Meta      Meta        `json:"meta,omitempty"`
 	}
+
+	// CustomerIterator iterates over customers, see Client.IterateCustomers
+	CustomerIterator struct {
+		*Iter
+	}
 )
 
 func (cm *Customer) String() string {
@@ -89,13 +105,31 @@ func (cm *Customer) AddMetadata(key, value string) {
 	cm.Metadata[key] = value
 }
 
+func (p *CustomerListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+
+	setValue(v, "currency", p.Currency)
+	return v, nil
+}
+
 // CreateCustomer creates a new customer object
 //
 // Relative endpoint: POST /customers
 func (c *Client) CreateCustomer(customer *Customer) error {
+	return c.CreateCustomerContext(context.Background(), customer)
+}
+
+// CreateCustomerContext is the same as CreateCustomer, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateCustomerContext(ctx context.Context, customer *Customer, opts ...RequestOption) error {
 	customerReq := &customerWrapper{customer}
 
-	err := c.post(customerEndpoint, customerReq, customerReq)
+	err := c.create(ctx, customerEndpoint, customerReq, customerReq, opts...)
 	if err != nil {
 		return err
 	}
@@ -106,23 +140,76 @@ func (c *Client) CreateCustomer(customer *Customer) error {
 // GetCustomers returns a cursor-paginated list of your customers.
 //
 // Relative endpoint: GET /customers
-func (c *Client) GetCustomers() (*CustomerListResponse, error) {
+func (c *Client) GetCustomers(params *CustomerListParams) (*CustomerListResponse, error) {
+	return c.GetCustomersContext(context.Background(), params)
+}
+
+// GetCustomersContext is the same as GetCustomers, but uses ctx for the request and applies opts to it.
+func (c *Client) GetCustomersContext(ctx context.Context, params *CustomerListParams, opts ...RequestOption) (*CustomerListResponse, error) {
+	if params == nil {
+		params = &CustomerListParams{}
+	}
+	path, err := listPath(customerEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
 	list := &CustomerListResponse{}
 
-	err := c.get(customerEndpoint, list)
+	err = c.get(ctx, path, list, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return list, err
 }
 
+// IterateCustomers returns an iterator over all your customers, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateCustomers(params *CustomerListParams) *CustomerIterator {
+	return c.IterateCustomersContext(context.Background(), params)
+}
+
+// IterateCustomersContext is the same as IterateCustomers, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateCustomersContext(ctx context.Context, params *CustomerListParams, opts ...RequestOption) *CustomerIterator {
+	if params == nil {
+		params = &CustomerListParams{}
+	}
+	p := *params
+
+	return &CustomerIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetCustomersContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.Customers))
+		for i, v := range list.Customers {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// Customer returns the customer the iterator advanced to
+func (it *CustomerIterator) Customer() *Customer {
+	v, _ := it.Current().(*Customer)
+	return v
+}
+
 // GetCustomer retrieves the details of an existing customer.
 //
 // Relative endpoint: GET /customers/CU123
 func (c *Client) GetCustomer(id string) (*Customer, error) {
+	return c.GetCustomerContext(context.Background(), id)
+}
+
+// GetCustomerContext is the same as GetCustomer, but uses ctx for the request and applies opts to it.
+func (c *Client) GetCustomerContext(ctx context.Context, id string, opts ...RequestOption) (*Customer, error) {
 	wrapper := &customerWrapper{}
 
-	err := c.get(fmt.Sprintf(`%s/%s`, customerEndpoint, id), wrapper)
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, customerEndpoint, id), wrapper, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -133,6 +220,11 @@ func (c *Client) GetCustomer(id string) (*Customer, error) {
 //
 // Relative endpoint: PUT /customers/CU123
 func (c *Client) UpdateCustomer(customer *Customer) error {
+	return c.UpdateCustomerContext(context.Background(), customer)
+}
+
+// UpdateCustomerContext is the same as UpdateCustomer, but uses ctx for the request and applies opts to it.
+func (c *Client) UpdateCustomerContext(ctx context.Context, customer *Customer, opts ...RequestOption) error {
 	id := customer.ID
 	// remove unpermitted keys before update
 	customer.ID = ""
@@ -140,7 +232,7 @@ func (c *Client) UpdateCustomer(customer *Customer) error {
 
 	customerReq := &customerWrapper{customer}
 
-	err := c.put(fmt.Sprintf(`%s/%s`, customerEndpoint, id), customerReq, customerReq)
+	err := c.put(ctx, fmt.Sprintf(`%s/%s`, customerEndpoint, id), customerReq, customerReq, opts...)
 	if err != nil {
 		return err
 	}
@@ -1,8 +1,11 @@
 package gocardless
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
 )
 
 const (
@@ -54,11 +57,27 @@ type (
 		CustomerBankAccount *CustomerBankAccount `json:"customer_bank_accounts"`
 	}
 
+	// CustomerBankAccountListParams parameters for listing bank accounts, nil lists the first page
+	CustomerBankAccountListParams struct {
+		ListParams
+		// CreatedAt limits to bank accounts created within the range
+		CreatedAt TimeRange
+		// Customer ID of a customer to filter bank accounts by
+		Customer string
+		// Enabled when set, limits to enabled or disabled bank accounts
+		Enabled *bool
+	}
+
 	// CustomerBankAccountListResponse a List response of CustomerBankAccount instances
 	CustomerBankAccountListResponse struct {
 		CustomerBankAccounts []*CustomerBankAccount `json:"customer_bank_accounts"`
 		Meta                 Meta                   `json:"meta,omitempty"`
 	}
+
+	// CustomerBankAccountIterator iterates over bank accounts, see Client.IterateCustomerBankAccounts
+	CustomerBankAccountIterator struct {
+		*Iter
+	}
 )
 
 func (ca *CustomerBankAccount) String() string {
@@ -82,13 +101,52 @@ func (ca *CustomerBankAccount) AddMetadata(key, value string) {
 	ca.Metadata[key] = value
 }
 
-// CreateCustomerBankAccount creates a new customer bank account object.
+func (p *CustomerBankAccountListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+
+	setValue(v, "customer", p.Customer)
+	if p.Enabled != nil {
+		v.Set("enabled", strconv.FormatBool(*p.Enabled))
+	}
+	return v, nil
+}
+
+// CreateCustomerBankAccount creates a new customer bank account object. The bank details
+// are looked up first when the client was created with WithBankDetailsLookup.
 //
 // Relative endpoint: POST /customer_bank_accounts
 func (c *Client) CreateCustomerBankAccount(cba *CustomerBankAccount) error {
+	return c.CreateCustomerBankAccountContext(context.Background(), cba)
+}
+
+// CreateCustomerBankAccountContext is the same as CreateCustomerBankAccount, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateCustomerBankAccountContext(ctx context.Context, cba *CustomerBankAccount, opts ...RequestOption) error {
+	if c.lookupBankDetails && cba.Links.CustomerBankAccountToken == "" {
+		lookup, err := c.LookupBankDetailsContext(ctx, &BankDetailsLookupParams{
+			AccountHolderName: cba.AccountHolderName,
+			AccountNumber:     cba.AccountNumber,
+			BankCode:          cba.BankCode,
+			BranchCode:        cba.BranchCode,
+			CountryCode:       cba.CountryCode,
+			IBAN:              cba.IBAN,
+		})
+		if err != nil {
+			return err
+		}
+		if lookup != nil && len(lookup.AvailableDebitSchemes) == 0 {
+			return &NoDebitSchemeError{Lookup: lookup}
+		}
+	}
+
 	cbaReq := &customerBankAccountWrapper{cba}
 
-	err := c.post(bankAccountEndpoint, cbaReq, cbaReq)
+	err := c.create(ctx, bankAccountEndpoint, cbaReq, cbaReq, opts...)
 	if err != nil {
 		return err
 	}
@@ -99,23 +157,76 @@ func (c *Client) CreateCustomerBankAccount(cba *CustomerBankAccount) error {
 // GetCustomerBankAccounts returns a cursor-paginated list of your bank accounts.
 //
 // Relative endpoint: GET /customer_bank_accounts
-func (c *Client) GetCustomerBankAccounts() (*CustomerBankAccountListResponse, error) {
+func (c *Client) GetCustomerBankAccounts(params *CustomerBankAccountListParams) (*CustomerBankAccountListResponse, error) {
+	return c.GetCustomerBankAccountsContext(context.Background(), params)
+}
+
+// GetCustomerBankAccountsContext is the same as GetCustomerBankAccounts, but uses ctx for the request and applies opts to it.
+func (c *Client) GetCustomerBankAccountsContext(ctx context.Context, params *CustomerBankAccountListParams, opts ...RequestOption) (*CustomerBankAccountListResponse, error) {
+	if params == nil {
+		params = &CustomerBankAccountListParams{}
+	}
+	path, err := listPath(bankAccountEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
 	list := &CustomerBankAccountListResponse{}
 
-	err := c.get(bankAccountEndpoint, list)
+	err = c.get(ctx, path, list, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return list, err
 }
 
+// IterateCustomerBankAccounts returns an iterator over all your bank accounts, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateCustomerBankAccounts(params *CustomerBankAccountListParams) *CustomerBankAccountIterator {
+	return c.IterateCustomerBankAccountsContext(context.Background(), params)
+}
+
+// IterateCustomerBankAccountsContext is the same as IterateCustomerBankAccounts, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateCustomerBankAccountsContext(ctx context.Context, params *CustomerBankAccountListParams, opts ...RequestOption) *CustomerBankAccountIterator {
+	if params == nil {
+		params = &CustomerBankAccountListParams{}
+	}
+	p := *params
+
+	return &CustomerBankAccountIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetCustomerBankAccountsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.CustomerBankAccounts))
+		for i, v := range list.CustomerBankAccounts {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// CustomerBankAccount returns the bank account the iterator advanced to
+func (it *CustomerBankAccountIterator) CustomerBankAccount() *CustomerBankAccount {
+	v, _ := it.Current().(*CustomerBankAccount)
+	return v
+}
+
 // GetCustomerBankAccount Retrieves the details of an existing bank account.
 //
 // Relative endpoint: GET /customer_bank_accounts/BA123
 func (c *Client) GetCustomerBankAccount(id string) (*CustomerBankAccount, error) {
+	return c.GetCustomerBankAccountContext(context.Background(), id)
+}
+
+// GetCustomerBankAccountContext is the same as GetCustomerBankAccount, but uses ctx for the request and applies opts to it.
+func (c *Client) GetCustomerBankAccountContext(ctx context.Context, id string, opts ...RequestOption) (*CustomerBankAccount, error) {
 	wrapper := &customerBankAccountWrapper{}
 
-	err := c.get(fmt.Sprintf(`%s/%s`, bankAccountEndpoint, id), wrapper)
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, bankAccountEndpoint, id), wrapper, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -126,6 +237,11 @@ func (c *Client) GetCustomerBankAccount(id string) (*CustomerBankAccount, error)
 //
 // Relative endpoint: PUT /customer_bank_accounts/BA123
 func (c *Client) UpdateCustomerBankAccount(cba *CustomerBankAccount) error {
+	return c.UpdateCustomerBankAccountContext(context.Background(), cba)
+}
+
+// UpdateCustomerBankAccountContext is the same as UpdateCustomerBankAccount, but uses ctx for the request and applies opts to it.
+func (c *Client) UpdateCustomerBankAccountContext(ctx context.Context, cba *CustomerBankAccount, opts ...RequestOption) error {
 	// remove unpermitted keys before update
 	cbaMeta := map[string]interface{}{
 		"customer_bank_accounts": map[string]interface{}{
@@ -134,7 +250,7 @@ func (c *Client) UpdateCustomerBankAccount(cba *CustomerBankAccount) error {
 	}
 	cbaRes := &customerBankAccountWrapper{cba}
 
-	err := c.put(fmt.Sprintf(`%s/%s`, bankAccountEndpoint, cba.ID), cbaMeta, cbaRes)
+	err := c.put(ctx, fmt.Sprintf(`%s/%s`, bankAccountEndpoint, cba.ID), cbaMeta, cbaRes, opts...)
 	if err != nil {
 		return err
 	}
@@ -146,8 +262,13 @@ func (c *Client) UpdateCustomerBankAccount(cba *CustomerBankAccount) error {
 //
 // Relative endpoint: POST /customer_bank_accounts/BA123/actions/disable
 func (c *Client) DisableCustomerBankAccount(id string) (*CustomerBankAccount, error) {
+	return c.DisableCustomerBankAccountContext(context.Background(), id)
+}
+
+// DisableCustomerBankAccountContext is the same as DisableCustomerBankAccount, but uses ctx for the request and applies opts to it.
+func (c *Client) DisableCustomerBankAccountContext(ctx context.Context, id string, opts ...RequestOption) (*CustomerBankAccount, error) {
 	wrapper := &customerBankAccountWrapper{}
-	err := c.post(fmt.Sprintf(`%s/%s/actions/disable`, bankAccountEndpoint, id), nil, wrapper)
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/disable`, bankAccountEndpoint, id), nil, wrapper, opts...)
 	if err != nil {
 		return nil, err
 	}
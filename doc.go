@@ -17,16 +17,56 @@ Create a Client instance, providing your access token and the environment you wa
     token := os.Getenv("GOCARDLESS_ACCESS_TOKEN")
 
     // gocardless client using Sandbox environment
-    client := gocardless.NewClient(token, gocardless.SandboxEnvironment)
+    client, err := gocardless.NewClient(token, gocardless.SandboxEnvironment)
+    if err != nil {
+      panic(err)
+    }
 
     // get customers
-    res, err := client.GetCustomers()
+    res, err := client.GetCustomers(nil)
 
     if err != nil {
       fmt.Println(err)
     }
     fmt.Println(res)
   }
+
+NewClient accepts options such as WithHTTPClient, WithTransport, WithRemoteURL, WithTimeout, WithUserAgent,
+WithHeader and WithRetryPolicy to customise how requests are sent.
+
+WithMiddleware wraps every request in a chain of Middleware, which see the method, path, headers and body of
+the request along with its response or error. HeaderMiddleware, LoggingMiddleware and DebugMiddleware are
+provided, custom ones are plain functions:
+
+  timing := func(next gocardless.Handler) gocardless.Handler {
+    return func(ctx context.Context, req *gocardless.Request) (*gocardless.Response, error) {
+      start := time.Now()
+      resp, err := next(ctx, req)
+      metrics.Observe(req.Method, req.Path, time.Since(start))
+      return resp, err
+    }
+  }
+
+A Client tracks the RateLimit headers of every response and holds requests back once the budget of the current
+window is exhausted, see Client.RateLimitStats and WithoutRateLimiter.
+
+List methods such as GetPayments return a single page, use Meta.NextPage to fetch the following one or
+IteratePayments and friends to walk every page lazily. With Go 1.23 and later the iterators' All method can be
+used with range:
+
+  for payment, err := range client.IteratePayments(nil).All() {
+    ...
+  }
+
+API errors are returned as one of ValidationFailedError, InvalidStateError, InvalidAPIUsageError,
+GoCardlessInternalError or RateLimitError, all wrapping an *Error, while network failures are returned as
+TransportError. Use errors.As to branch on them and errors.Is with the Reason constants, e.g.
+ErrMandateIsInactive, to match the reason of the error details.
+
+Every resource method has a Context variant, e.g. GetCustomersContext, which accepts a context.Context
+that is attached to the underlying HTTP request for cancellation and deadlines, and RequestOptions such as
+WithIdempotencyKey or RecordResponse which apply to that request only.
+
 Learn more about GoCardless Pro API https://developer.gocardless.com/
 */
 package gocardless
@@ -2,6 +2,9 @@ package gocardless
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
 )
 
 const (
@@ -9,12 +12,75 @@ const (
 	InvalidMethodError = `The request Method is invalid`
 )
 
+// Types of API errors, see Error.Type
+const (
+	// ErrorTypeInvalidAPIUsage is returned when the request is malformed or not permitted
+	ErrorTypeInvalidAPIUsage = "invalid_api_usage"
+	// ErrorTypeInvalidState is returned when the action is not possible in the resource's current state
+	ErrorTypeInvalidState = "invalid_state"
+	// ErrorTypeValidationFailed is returned when the request parameters are invalid
+	ErrorTypeValidationFailed = "validation_failed"
+	// ErrorTypeGoCardless is returned when an internal error occurred at GoCardless
+	ErrorTypeGoCardless = "gocardless"
+)
+
+// Reason is the machine readable reason of an ErrorDetail. API errors match a
+// Reason with errors.Is when any of their details carries it, e.g.
+//
+//	if errors.Is(err, gocardless.ErrMandateIsInactive) { ... }
+type Reason string
+
+func (r Reason) Error() string {
+	return string(r)
+}
+
+// Reasons of API errors, see ErrorDetail.Reason
+const (
+	ErrInvalidType                  Reason = "invalid_type"
+	ErrPathNotFound                 Reason = "path_not_found"
+	ErrLinkNotFound                 Reason = "link_not_found"
+	ErrUnknownParameter             Reason = "unknown_parameter"
+	ErrInvalidFilters               Reason = "invalid_filters"
+	ErrMissingAuthorizationHeader   Reason = "missing_authorization_header"
+	ErrInvalidAuthorizationHeader   Reason = "invalid_authorization_header"
+	ErrInsufficientPermissions      Reason = "insufficient_permissions"
+	ErrUnauthorized                 Reason = "unauthorized"
+	ErrRateLimitExceeded            Reason = "rate_limit_exceeded"
+	ErrIdempotentCreationConflict   Reason = "idempotent_creation_conflict"
+	ErrIdempotencyKeyTooLong        Reason = "idempotency_key_too_long"
+	ErrMandateIsInactive            Reason = "mandate_is_inactive"
+	ErrMandateReplaced              Reason = "mandate_replaced"
+	ErrBankAccountDisabled          Reason = "bank_account_disabled"
+	ErrBankAccountExists            Reason = "bank_account_exists"
+	ErrCancellationFailed           Reason = "cancellation_failed"
+	ErrRetryFailed                  Reason = "retry_failed"
+	ErrCustomerBankAccountTokenUsed Reason = "customer_bank_account_token_used"
+	ErrAvailableDebitSchemeNotFound Reason = "available_debit_scheme_not_found"
+	ErrInternalError                Reason = "internal_error"
+)
+
+// ErrInvalidParams is returned, wrapped, when list parameters are invalid, before any request is sent
+var ErrInvalidParams = errors.New("gocardless: invalid parameters")
+
+// ErrRefundExceedsPayment is returned, wrapped, by Payment.NewRefund when the refund
+// is larger than the amount of the payment not refunded yet
+var ErrRefundExceedsPayment = errors.New("gocardless: refund exceeds refundable amount")
+
+// ErrInstalmentAmountsMismatch is returned, wrapped, by InstalmentSchedule.Validate when the
+// amounts of the instalments do not add up to the total amount of the schedule
+var ErrInstalmentAmountsMismatch = errors.New("gocardless: instalment amounts do not add up to total amount")
+
+// ErrMandateImportEntriesFailed is returned, wrapped, by Client.ImportMandates when some
+// entries could not be added, the import is then cancelled instead of submitted
+var ErrMandateImportEntriesFailed = errors.New("gocardless: mandate import entries failed")
+
 type errorContainer struct {
 	Error *Error `json:"error"`
 }
 
 // Error base exception class for GoCardless API errors.
-// API errors will result in of this
+// API errors will result in of this, wrapped in one of the typed errors below
+// depending on Type. Use errors.As to get to it from any of them.
 type Error struct {
 	DocumentationURL string         `json:"documentation_url"`
 	Message          string         `json:"message"`
@@ -29,19 +95,171 @@ func (err Error) Error() string {
 	return string(data)
 }
 
+// Is reports whether target is a Reason carried by any of the error details
+func (err *Error) Is(target error) bool {
+	reason, ok := target.(Reason)
+	if !ok {
+		return false
+	}
+	for _, d := range err.Details {
+		if d.Reason == string(reason) {
+			return true
+		}
+	}
+	return false
+}
+
 // ErrorDetail a struct containing the reason for the errors
 type ErrorDetail struct {
 	Message        string `json:"message"`
 	Field          string `json:"field"`
 	RequestPointer string `json:"request_pointer"`
+	// Reason is a machine readable reason, compare it with the Reason constants
+	Reason string `json:"reason,omitempty"`
+	// Links holds the IDs of resources related to the error, e.g. conflicting_resource_id
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// apiError is an alias of Error, embedding it under this name lets the typed errors
+// below promote both the fields and the Error method of Error
+type apiError = Error
+
+// ValidationFailedError is returned when the request parameters are invalid,
+// Details lists the offending fields
+type ValidationFailedError struct {
+	*apiError
+}
+
+// Unwrap returns the underlying API error
+func (err *ValidationFailedError) Unwrap() error {
+	return err.apiError
+}
+
+// InvalidStateError is returned when the action is not possible in the resource's
+// current state, e.g. cancelling an already cancelled mandate
+type InvalidStateError struct {
+	*apiError
+}
+
+// Unwrap returns the underlying API error
+func (err *InvalidStateError) Unwrap() error {
+	return err.apiError
+}
+
+// InvalidAPIUsageError is returned when the request is malformed, unauthorised or
+// refers to a resource that does not exist
+type InvalidAPIUsageError struct {
+	*apiError
+}
+
+// Unwrap returns the underlying API error
+func (err *InvalidAPIUsageError) Unwrap() error {
+	return err.apiError
+}
+
+// GoCardlessInternalError is returned when GoCardless failed to process a valid request,
+// it is usually safe to retry the request
+type GoCardlessInternalError struct {
+	*apiError
+}
+
+// Unwrap returns the underlying API error
+func (err *GoCardlessInternalError) Unwrap() error {
+	return err.apiError
+}
+
+// RateLimitError is returned when the API responds with 429 Too Many Requests
+type RateLimitError struct {
+	*apiError
+	// Reset is the time after which the rate limit resets, zero when unknown
+	Reset time.Time
+}
+
+func (err *RateLimitError) Error() string {
+	if err.apiError == nil {
+		return `Rate Limit exceeded`
+	}
+	return err.apiError.Error()
+}
+
+// Unwrap returns the underlying API error
+func (err *RateLimitError) Unwrap() error {
+	if err.apiError == nil {
+		return nil
+	}
+	return err.apiError
 }
 
 // RateLimitedExceededError rate limit error
-type RateLimitedExceededError struct {
+//
+// Deprecated: use RateLimitError
+type RateLimitedExceededError = RateLimitError
+
+// NoDebitSchemeError is returned by CreateCustomerBankAccount when WithBankDetailsLookup is
+// used and the bank account cannot be debited by any scheme. It matches
+// ErrAvailableDebitSchemeNotFound with errors.Is, like the error of the API would.
+type NoDebitSchemeError struct {
+	// Lookup is the result of the bank details lookup
+	Lookup *BankDetailsLookup
+}
+
+func (err *NoDebitSchemeError) Error() string {
+	if err.Lookup == nil || err.Lookup.BankName == "" {
+		return "gocardless: no debit scheme available for the bank account"
+	}
+	return fmt.Sprintf("gocardless: no debit scheme available for the bank account at %s", err.Lookup.BankName)
+}
+
+// Is reports whether target is ErrAvailableDebitSchemeNotFound
+func (err *NoDebitSchemeError) Is(target error) bool {
+	return target == ErrAvailableDebitSchemeNotFound
+}
+
+// TransportError is returned when a request could not be sent or its response
+// could not be read, Err holds the underlying cause
+type TransportError struct {
+	Method string
+	URL    string
+	Err    error
+}
+
+func (err *TransportError) Error() string {
+	return fmt.Sprintf("gocardless: %s %s: %v", err.Method, err.URL, err.Err)
+}
+
+// Unwrap returns the underlying cause
+func (err *TransportError) Unwrap() error {
+	return err.Err
+}
+
+// typedError wraps err in the typed error matching its Type
+func typedError(err *Error) error {
+	switch err.Type {
+	case ErrorTypeValidationFailed:
+		return &ValidationFailedError{err}
+	case ErrorTypeInvalidState:
+		return &InvalidStateError{err}
+	case ErrorTypeInvalidAPIUsage:
+		return &InvalidAPIUsageError{err}
+	case ErrorTypeGoCardless:
+		return &GoCardlessInternalError{err}
+	}
+	return err
 }
 
-func (err *RateLimitedExceededError) Error() string {
-	return `Rate Limit exceeded`
+// conflictingResourceID returns the ID of the resource an idempotent creation
+// conflict refers to, or an empty string if err is not such a conflict
+func conflictingResourceID(err error) string {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	for _, d := range apiErr.Details {
+		if d.Reason == string(ErrIdempotentCreationConflict) {
+			return d.Links["conflicting_resource_id"]
+		}
+	}
+	return ""
 }
 
 // InvalidEnvironment invalid environment exception
@@ -0,0 +1,123 @@
+package gocardless
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTypedErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		check  func(err error) bool
+	}{
+		{
+			name:   "validation failed",
+			status: http.StatusUnprocessableEntity,
+			body:   `{"error":{"type":"validation_failed","code":422,"errors":[{"field":"amount","message":"must be positive"}]}}`,
+			check: func(err error) bool {
+				var target *ValidationFailedError
+				return errors.As(err, &target) && target.Details[0].Field == "amount"
+			},
+		},
+		{
+			name:   "invalid state",
+			status: http.StatusUnprocessableEntity,
+			body:   `{"error":{"type":"invalid_state","code":422,"errors":[{"reason":"mandate_is_inactive"}]}}`,
+			check: func(err error) bool {
+				var target *InvalidStateError
+				return errors.As(err, &target) && errors.Is(err, ErrMandateIsInactive) && !errors.Is(err, ErrMandateReplaced)
+			},
+		},
+		{
+			name:   "invalid api usage",
+			status: http.StatusNotFound,
+			body:   `{"error":{"type":"invalid_api_usage","code":404,"errors":[{"reason":"path_not_found"}]}}`,
+			check: func(err error) bool {
+				var target *InvalidAPIUsageError
+				return errors.As(err, &target) && errors.Is(err, ErrPathNotFound)
+			},
+		},
+		{
+			name:   "internal error",
+			status: http.StatusInternalServerError,
+			body:   `{"error":{"type":"gocardless","code":500,"errors":[{"reason":"internal_error"}]}}`,
+			check: func(err error) bool {
+				var target *GoCardlessInternalError
+				return errors.As(err, &target) && errors.Is(err, ErrInternalError)
+			},
+		},
+		{
+			name:   "non json server error",
+			status: http.StatusBadGateway,
+			body:   `<html>Bad Gateway</html>`,
+			check: func(err error) bool {
+				var target *GoCardlessInternalError
+				return errors.As(err, &target) && target.Code == http.StatusBadGateway && target.Message == "<html>Bad Gateway</html>"
+			},
+		},
+		{
+			name:   "rate limited",
+			status: http.StatusTooManyRequests,
+			body:   `{"error":{"type":"invalid_api_usage","code":429,"errors":[{"reason":"rate_limit_exceeded"}]}}`,
+			check: func(err error) bool {
+				var target *RateLimitError
+				return errors.As(err, &target) && !target.Reset.IsZero() && errors.Is(err, ErrRateLimitExceeded)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "RQ123")
+				w.Header().Set("RateLimit-Reset", time.Now().Add(time.Minute).UTC().Format(time.RFC1123))
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}, WithoutRateLimiter())
+			defer srv.Close()
+
+			_, err := c.GetMandate("MD123")
+			if !tt.check(err) {
+				t.Fatalf("unexpected error %#v", err)
+			}
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an Error, got %#v", err)
+			}
+			if apiErr.Code != tt.status || apiErr.RequestID != "RQ123" {
+				t.Errorf("unexpected code %d and request id %q", apiErr.Code, apiErr.RequestID)
+			}
+		})
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	srv.Close()
+
+	_, err := c.GetMandate("MD123")
+	var target *TransportError
+	if !errors.As(err, &target) {
+		t.Fatalf("expected a TransportError, got %#v", err)
+	}
+	if target.Method != http.MethodGet || target.Err == nil {
+		t.Errorf("unexpected transport error %#v", target)
+	}
+}
+
+func TestConflictingResourceID(t *testing.T) {
+	err := &InvalidStateError{&Error{Details: []*ErrorDetail{
+		{Reason: string(ErrIdempotentCreationConflict), Links: map[string]string{"conflicting_resource_id": "PM123"}},
+	}}}
+	if id := conflictingResourceID(err); id != "PM123" {
+		t.Errorf("expected PM123, got %q", id)
+	}
+	if id := conflictingResourceID(errors.New("other")); id != "" {
+		t.Errorf("expected no id, got %q", id)
+	}
+}
@@ -0,0 +1,276 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	eventEndpoint = "events"
+)
+
+// Resource types of events, see Event.ResourceType
+const (
+	EventResourceBillingRequests     = "billing_requests"
+	EventResourceCreditors           = "creditors"
+	EventResourceInstalmentSchedules = "instalment_schedules"
+	EventResourceMandates            = "mandates"
+	EventResourcePayments            = "payments"
+	EventResourcePayouts             = "payouts"
+	EventResourceRefunds             = "refunds"
+	EventResourceSubscriptions       = "subscriptions"
+)
+
+type (
+	// Event Events are stored for all webhooks. An event refers to a resource which has been
+	// updated, for example a payment which has been collected, or a mandate which has been transferred.
+	Event struct {
+		// ID is a unique identifier, beginning with "EV".
+		ID string `json:"id"`
+		// Action What has happened to the resource, e.g. "created", "submitted" or "paid_out"
+		Action string `json:"action"`
+		// CreatedAt is a fixed timestamp, recording when the event was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Details describe why the event happened
+		Details EventDetails `json:"details"`
+		// Metadata is a key-value store of custom data. Up to 3 keys are permitted, with key names up to 50
+		// characters and values up to 500 characters.
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// ResourceType The resource type for this event, one of the EventResource constants
+		ResourceType string `json:"resource_type"`
+		// Links links to the resource the event is about and to related resources
+		Links EventLinks `json:"links"`
+	}
+
+	// EventDetails describe the origin and cause of an event
+	EventDetails struct {
+		// Origin Who initiated the event, one of "bank", "api", "gocardless" or "customer"
+		Origin string `json:"origin"`
+		// Cause What triggered the event, e.g. "payment_paid_out" or "mandate_cancelled"
+		Cause string `json:"cause"`
+		// Description Human readable description of the cause. Note: Changes to event
+		// descriptions are not considered breaking.
+		Description string `json:"description"`
+		// Scheme Set when a bank is the origin of the event, the Direct Debit scheme it belongs to
+		Scheme string `json:"scheme,omitempty"`
+		// ReasonCode Set when a bank is the origin of the event, the scheme's reason code
+		ReasonCode string `json:"reason_code,omitempty"`
+		// NotRetriedReason When WillAttemptRetry is false, why the payment will not be retried
+		NotRetriedReason string `json:"not_retried_reason,omitempty"`
+		// Property the property of the resource that changed, for update events
+		Property string `json:"property,omitempty"`
+		// WillAttemptRetry whether the payment will be retried automatically
+		WillAttemptRetry bool `json:"will_attempt_retry,omitempty"`
+	}
+
+	// EventLinks IDs of the resources an event relates to, only the ones relevant to
+	// the event's ResourceType are set
+	EventLinks struct {
+		BillingRequestID              string `json:"billing_request,omitempty"`
+		CreditorID                    string `json:"creditor,omitempty"`
+		CustomerID                    string `json:"customer,omitempty"`
+		CustomerBankAccountID         string `json:"customer_bank_account,omitempty"`
+		InstalmentScheduleID          string `json:"instalment_schedule,omitempty"`
+		MandateID                     string `json:"mandate,omitempty"`
+		NewCustomerBankAccountID      string `json:"new_customer_bank_account,omitempty"`
+		NewMandateID                  string `json:"new_mandate,omitempty"`
+		OrganisationID                string `json:"organisation,omitempty"`
+		ParentEventID                 string `json:"parent_event,omitempty"`
+		PaymentID                     string `json:"payment,omitempty"`
+		PayoutID                      string `json:"payout,omitempty"`
+		PreviousCustomerBankAccountID string `json:"previous_customer_bank_account,omitempty"`
+		RefundID                      string `json:"refund,omitempty"`
+		SubscriptionID                string `json:"subscription,omitempty"`
+	}
+
+	// eventWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	eventWrapper struct {
+		Event *Event `json:"events"`
+	}
+
+	// EventListParams parameters for listing events, nil lists the first page.
+	// At most one of the linked resource IDs can be used.
+	EventListParams struct {
+		ListParams
+		// Action limits to events with the action, e.g. "paid_out"
+		Action string
+		// CreatedAt limits to events created within the range
+		CreatedAt TimeRange
+		// Include embeds the linked resource of each event in EventListResponse.Linked, e.g.
+		// "payment". ResourceType must be set to the matching type, e.g. EventResourcePayments
+		Include string
+		// ResourceType limits to events about one type of resource, one of the EventResource constants
+		ResourceType string
+		// BillingRequest ID of a billing request to list events of
+		BillingRequest string
+		// Creditor ID of a creditor to list events of
+		Creditor string
+		// InstalmentSchedule ID of an instalment schedule to list events of
+		InstalmentSchedule string
+		// Mandate ID of a mandate to list events of
+		Mandate string
+		// ParentEvent ID of an event to list the child events of
+		ParentEvent string
+		// Payment ID of a payment to list events of
+		Payment string
+		// Payout ID of a payout to list events of
+		Payout string
+		// Refund ID of a refund to list events of
+		Refund string
+		// Subscription ID of a subscription to list events of
+		Subscription string
+	}
+
+	// EventListResponse a List response of Event instances
+	EventListResponse struct {
+		Events []*Event `json:"events"`
+		// Linked holds the resources embedded with EventListParams.Include
+		Linked EventLinked `json:"linked,omitempty"`
+		Meta   Meta        `json:"meta,omitempty"`
+	}
+
+	// EventLinked resources embedded in a list of events
+	EventLinked struct {
+		BillingRequests     []*BillingRequest     `json:"billing_requests,omitempty"`
+		Creditors           []*Creditor           `json:"creditors,omitempty"`
+		InstalmentSchedules []*InstalmentSchedule `json:"instalment_schedules,omitempty"`
+		Mandates            []*Mandate            `json:"mandates,omitempty"`
+		Payments            []*Payment            `json:"payments,omitempty"`
+		Payouts             []*Payout             `json:"payouts,omitempty"`
+		Refunds             []*Refund             `json:"refunds,omitempty"`
+		Subscriptions       []*Subscription       `json:"subscriptions,omitempty"`
+	}
+
+	// EventIterator iterates over events, see Client.IterateEvents
+	EventIterator struct {
+		*Iter
+	}
+)
+
+func (e *Event) String() string {
+	bs, _ := json.Marshal(e)
+	return string(bs)
+}
+
+func (p *EventListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+	if p.Include != "" && p.ResourceType == "" {
+		return nil, fmt.Errorf("%w: include requires resource_type", ErrInvalidParams)
+	}
+
+	links := map[string]string{
+		"billing_request":     p.BillingRequest,
+		"creditor":            p.Creditor,
+		"instalment_schedule": p.InstalmentSchedule,
+		"mandate":             p.Mandate,
+		"parent_event":        p.ParentEvent,
+		"payment":             p.Payment,
+		"payout":              p.Payout,
+		"refund":              p.Refund,
+		"subscription":        p.Subscription,
+	}
+	linked := 0
+	for key, id := range links {
+		if id != "" {
+			linked++
+			v.Set(key, id)
+		}
+	}
+	if linked > 1 {
+		return nil, fmt.Errorf("%w: only one linked resource ID can be used", ErrInvalidParams)
+	}
+
+	setValue(v, "action", p.Action)
+	setValue(v, "include", p.Include)
+	setValue(v, "resource_type", p.ResourceType)
+	return v, nil
+}
+
+// GetEvents returns a cursor-paginated list of your events.
+//
+// Relative endpoint: GET /events
+func (c *Client) GetEvents(params *EventListParams) (*EventListResponse, error) {
+	return c.GetEventsContext(context.Background(), params)
+}
+
+// GetEventsContext is the same as GetEvents, but uses ctx for the request and applies opts to it.
+func (c *Client) GetEventsContext(ctx context.Context, params *EventListParams, opts ...RequestOption) (*EventListResponse, error) {
+	if params == nil {
+		params = &EventListParams{}
+	}
+	path, err := listPath(eventEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &EventListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateEvents returns an iterator over all your events, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set. Resources embedded with Include are not available through
+// the iterator, use GetEvents instead.
+func (c *Client) IterateEvents(params *EventListParams) *EventIterator {
+	return c.IterateEventsContext(context.Background(), params)
+}
+
+// IterateEventsContext is the same as IterateEvents, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateEventsContext(ctx context.Context, params *EventListParams, opts ...RequestOption) *EventIterator {
+	if params == nil {
+		params = &EventListParams{}
+	}
+	p := *params
+
+	return &EventIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetEventsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.Events))
+		for i, v := range list.Events {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// Event returns the event the iterator advanced to
+func (it *EventIterator) Event() *Event {
+	v, _ := it.Current().(*Event)
+	return v
+}
+
+// GetEvent retrieves the details of a single event.
+//
+// Relative endpoint: GET /events/EV123
+func (c *Client) GetEvent(id string) (*Event, error) {
+	return c.GetEventContext(context.Background(), id)
+}
+
+// GetEventContext is the same as GetEvent, but uses ctx for the request and applies opts to it.
+func (c *Client) GetEventContext(ctx context.Context, id string, opts ...RequestOption) (*Event, error) {
+	wrapper := &eventWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, eventEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Event, err
+}
@@ -0,0 +1,123 @@
+package gocardless
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestGetEvents(t *testing.T) {
+	var query url.Values
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query = r.URL.Query()
+		w.Write([]byte(`{
+			"events":[{"id":"EV123","action":"paid_out","resource_type":"payments","details":{"origin":"gocardless","cause":"payment_paid_out"},"links":{"payment":"PM123","payout":"PO123"}}],
+			"linked":{"payments":[{"id":"PM123","amount":1000}]},
+			"meta":{"cursors":{},"limit":50}
+		}`))
+	})
+	defer srv.Close()
+
+	res, err := c.GetEvents(&EventListParams{
+		Action:       "paid_out",
+		Include:      "payment",
+		ResourceType: EventResourcePayments,
+		Payout:       "PO123",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := url.Values{"action": {"paid_out"}, "include": {"payment"}, "resource_type": {"payments"}, "payout": {"PO123"}}
+	if query.Encode() != want.Encode() {
+		t.Errorf("expected query %q, got %q", want.Encode(), query.Encode())
+	}
+
+	if len(res.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(res.Events))
+	}
+	e := res.Events[0]
+	if e.ID != "EV123" || e.Details.Cause != "payment_paid_out" || e.Links.PaymentID != "PM123" || e.Links.PayoutID != "PO123" {
+		t.Errorf("unexpected event %v", e)
+	}
+	if len(res.Linked.Payments) != 1 || res.Linked.Payments[0].Amount != 1000 {
+		t.Errorf("expected the linked payment, got %+v", res.Linked)
+	}
+}
+
+func TestGetEventsInvalidParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *EventListParams
+	}{
+		{name: "include without resource type", params: &EventListParams{Include: "payment"}},
+		{name: "several linked resources", params: &EventListParams{Mandate: "MD123", Payment: "PM123"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+			})
+			defer srv.Close()
+
+			if _, err := c.GetEvents(tt.params); !errors.Is(err, ErrInvalidParams) {
+				t.Errorf("expected ErrInvalidParams, got %v", err)
+			}
+			if calls != 0 {
+				t.Errorf("expected no request, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestGetEvent(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events/EV123" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"events":{"id":"EV123","action":"failed","resource_type":"payments","details":{"origin":"bank","cause":"insufficient_funds","scheme":"bacs","reason_code":"ARUDD-0","will_attempt_retry":true},"links":{"payment":"PM123"}}}`))
+	})
+	defer srv.Close()
+
+	e, err := c.GetEvent("EV123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := e.Details
+	if d.Origin != "bank" || d.Scheme != "bacs" || d.ReasonCode != "ARUDD-0" || !d.WillAttemptRetry {
+		t.Errorf("unexpected details %+v", d)
+	}
+	if e.ResourceType != EventResourcePayments || e.Links.PaymentID != "PM123" {
+		t.Errorf("unexpected event %v", e)
+	}
+}
+
+func TestIterateEvents(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("mandate") != "MD123" {
+			t.Errorf("expected the filter on every page, got %q", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("after") == "" {
+			w.Write([]byte(`{"events":[{"id":"EV1"}],"meta":{"cursors":{"after":"EV1"},"limit":1}}`))
+			return
+		}
+		w.Write([]byte(`{"events":[{"id":"EV2"}],"meta":{"cursors":{"before":"EV2"},"limit":1}}`))
+	})
+	defer srv.Close()
+
+	it := c.IterateEvents(&EventListParams{Mandate: "MD123"})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Event().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "EV1" || ids[1] != "EV2" {
+		t.Errorf("unexpected events %v", ids)
+	}
+}
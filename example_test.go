@@ -1,19 +1,25 @@
 package gocardless
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"time"
 )
 
 func ExampleCustomer() {
 	// Create a Client instance, providing your access token and the environment you want to use
 	token := os.Getenv("GOCARDLESS_ACCESS_TOKEN")
-	client := NewClient(token, SandboxEnvironment)
+	client, err := NewClient(token, SandboxEnvironment)
+	if err != nil {
+		panic(err)
+	}
 
 	// create customer
 	cm := NewCustomer("user@example.com", "Frank", "Osborne", "27 Acer Road", "Apt 2", "London", "E8 3GX", "GB")
 	cm.AddMetadata("salesforce_id", "ABCD1234")
-	err := client.CreateCustomer(cm)
+	err = client.CreateCustomer(cm)
 
 	if err != nil {
 		panic(err)
@@ -27,7 +33,7 @@ func ExampleCustomer() {
 	}
 	fmt.Println(cm)
 	// get customers
-	res, err := client.GetCustomers()
+	res, err := client.GetCustomers(nil)
 	if err != nil {
 		panic(err)
 	}
@@ -41,3 +47,87 @@ func ExampleCustomer() {
 	}
 	fmt.Println(cm)
 }
+
+func ExampleNewClient() {
+	token := os.Getenv("GOCARDLESS_ACCESS_TOKEN")
+
+	// share a single http.Client between calls, with a custom timeout and headers
+	client, err := NewClient(token, SandboxEnvironment,
+		WithHTTPClient(&http.Client{}),
+		WithTimeout(10*time.Second),
+		WithUserAgent("billing-service/1.0"),
+		WithHeader("X-Correlation-Id", "abc123"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	res, err := client.GetCustomers(nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(res)
+}
+
+func ExampleWithIdempotencyKey() {
+	token := os.Getenv("GOCARDLESS_ACCESS_TOKEN")
+	client, err := NewClient(token, SandboxEnvironment, WithConflictResolution())
+	if err != nil {
+		panic(err)
+	}
+
+	// derive the key from our own order ID, so that submitting the same order twice
+	// returns the payment created the first time instead of charging again
+	payment := NewPayment(Centify(12.25), "GBP", "MD123")
+	err = client.CreatePaymentContext(context.Background(), payment, WithIdempotencyKey("order-4242"))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(payment)
+}
+
+func ExampleMeta_NextPage() {
+	token := os.Getenv("GOCARDLESS_ACCESS_TOKEN")
+	client, err := NewClient(token, SandboxEnvironment)
+	if err != nil {
+		panic(err)
+	}
+
+	params := &PaymentListParams{ListParams: ListParams{Limit: 100}}
+	for {
+		list, err := client.GetPayments(params)
+		if err != nil {
+			panic(err)
+		}
+		for _, p := range list.Payments {
+			fmt.Println(p)
+		}
+		if !list.Meta.HasNext() {
+			break
+		}
+		params.ListParams = list.Meta.NextPage()
+	}
+}
+
+func ExamplePayment_NewRefund() {
+	token := os.Getenv("GOCARDLESS_ACCESS_TOKEN")
+	client, err := NewClient(token, SandboxEnvironment)
+	if err != nil {
+		panic(err)
+	}
+
+	payment, err := client.GetPayment("PM123")
+	if err != nil {
+		panic(err)
+	}
+
+	// refuses to build a refund larger than what is left to refund
+	refund, err := payment.NewRefund(payment.RefundableAmount())
+	if err != nil {
+		panic(err)
+	}
+	if err := client.CreateRefund(refund); err != nil {
+		panic(err)
+	}
+	fmt.Println(refund)
+}
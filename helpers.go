@@ -1,6 +1,7 @@
 package gocardless
 
 import (
+	"reflect"
 	"strings"
 	"time"
 )
@@ -25,6 +26,32 @@ func (d *Date) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON encodes the date in the YYYY-MM-DD format expected by the API
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.Format("2006-01-02") + `"`), nil
+}
+
+// timePtr returns the date as a *time.Time, nil for a nil date
+func (d *Date) timePtr() *time.Time {
+	if d == nil {
+		return nil
+	}
+	return &d.Time
+}
+
+// isNil reports whether v is nil, or an interface holding a nil pointer, map, slice,
+// func or channel
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
 // Centify amount in floats by multiplying by 100, so 12.25 -> 1225.
 // Use when creating payments as amount should be in Pence or Cents
 func Centify(amount float64) int {
@@ -0,0 +1,378 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	instalmentScheduleEndpoint = "instalment_schedules"
+	// maxInstalmentScheduleStatusFilters is the number of statuses a list of instalment schedules can be filtered by
+	maxInstalmentScheduleStatusFilters = 6
+)
+
+// Statuses of instalment schedules, see InstalmentSchedule.Status
+const (
+	InstalmentScheduleStatusPending        = "pending"
+	InstalmentScheduleStatusActive         = "active"
+	InstalmentScheduleStatusCreationFailed = "creation_failed"
+	InstalmentScheduleStatusCompleted      = "completed"
+	InstalmentScheduleStatusCancelled      = "cancelled"
+	InstalmentScheduleStatusErrored        = "errored"
+)
+
+type (
+	// InstalmentSchedule Instalment schedules are objects which represent a collection of related payments,
+	// with the intention to collect the TotalAmount specified. Set either Instalments, to charge
+	// on explicit dates, or Plan, to charge at a regular interval, before creating it.
+	InstalmentSchedule struct {
+		// ID is a unique identifier, beginning with "IS".
+		ID string `json:"id,omitempty"`
+		// AppFee The amount to be deducted from each payment as an app fee, in pence/cents/öre/øre
+		AppFee int `json:"app_fee,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the instalment schedule was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Currency currency code of the payments
+		Currency string `json:"currency"`
+		// Instalments the payments to create, on explicit dates. Only used on creation
+		Instalments []*Instalment `json:"-"`
+		// Plan the payments to create, at a regular interval. Only used on creation
+		Plan *InstalmentPlan `json:"-"`
+		// Metadata is a key-value store of custom data. Up to 3 keys are permitted, with key names up to 50
+		// characters and values up to 500 characters.
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// Name Name of the instalment schedule, up to 100 chars. This name will also be copied to the
+		// payments of the instalment schedule if you use schedule-based creation.
+		Name string `json:"name"`
+		// PaymentErrors If the status is creation_failed, the validation failures of the individual
+		// payments, keyed by the index of the payment that failed
+		PaymentErrors map[string][]*ErrorDetail `json:"payment_errors,omitempty"`
+		// PaymentReference An optional reference that will appear on your customer’s bank statement
+		PaymentReference string `json:"payment_reference,omitempty"`
+		// RetryIfPossible On failure, automatically retry payments using intelligent retries
+		RetryIfPossible bool `json:"retry_if_possible,omitempty"`
+		// Status status of instalment schedule, one of the InstalmentScheduleStatus constants
+		Status string `json:"status,omitempty"`
+		// TotalAmount The total amount of the instalment schedule, in pence/cents/öre/øre.
+		// The amounts of the instalments must add up to it.
+		TotalAmount int `json:"total_amount"`
+		// Links links to the mandate, and once created to the customer and the payments
+		Links instalmentScheduleLinks `json:"links"`
+	}
+	instalmentScheduleLinks struct {
+		CustomerID string `json:"customer,omitempty"`
+		MandateID  string `json:"mandate,omitempty"`
+		// PaymentIDs IDs of the payments of the instalment schedule, see Client.GetInstalmentSchedulePayments
+		PaymentIDs []string `json:"payments,omitempty"`
+	}
+
+	// Instalment a payment of an instalment schedule charged on an explicit date
+	Instalment struct {
+		// Amount in pence/cents/öre/øre
+		Amount int `json:"amount"`
+		// ChargeDate A future date on which the payment should be collected.
+		// If not specified, the payment will be collected as soon as possible
+		ChargeDate *Date `json:"charge_date,omitempty"`
+		// Description A human-readable description of the payment
+		Description string `json:"description,omitempty"`
+	}
+
+	// InstalmentPlan the payments of an instalment schedule charged at a regular interval
+	InstalmentPlan struct {
+		// Amounts of the payments, in pence/cents/öre/øre
+		Amounts []int `json:"amounts"`
+		// Interval Number of IntervalUnit between charge dates. Must be greater than or equal to 1.
+		Interval int `json:"interval"`
+		// IntervalUnit The unit of time between charge dates. One of weekly, monthly or yearly
+		IntervalUnit string `json:"interval_unit"`
+		// StartDate The date on which the first payment should be charged, as soon as possible when not set
+		StartDate *Date `json:"start_date,omitempty"`
+	}
+
+	// instalmentScheduleRequest is a utility struct used to add the instalments, which are
+	// either a list or a plan, to the JSON request being passed to the remote API
+	instalmentScheduleRequest struct {
+		*InstalmentSchedule
+		Instalments interface{} `json:"instalments"`
+	}
+	// instalmentScheduleWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	instalmentScheduleWrapper struct {
+		InstalmentSchedule *InstalmentSchedule `json:"instalment_schedules"`
+	}
+
+	// InstalmentScheduleListParams parameters for listing instalment schedules, nil lists the first page
+	InstalmentScheduleListParams struct {
+		ListParams
+		// CreatedAt limits to instalment schedules created within the range
+		CreatedAt TimeRange
+		// Customer ID of a customer to filter instalment schedules by
+		Customer string
+		// Mandate ID of a mandate to filter instalment schedules by
+		Mandate string
+		// Status limits to instalment schedules with one of the statuses, at most 6
+		Status []string
+	}
+
+	// InstalmentScheduleListResponse a List response of InstalmentSchedule instances
+	InstalmentScheduleListResponse struct {
+		InstalmentSchedules []*InstalmentSchedule `json:"instalment_schedules"`
+		Meta                Meta                  `json:"meta,omitempty"`
+	}
+
+	// InstalmentScheduleIterator iterates over instalment schedules, see Client.IterateInstalmentSchedules
+	InstalmentScheduleIterator struct {
+		*Iter
+	}
+)
+
+func (s *InstalmentSchedule) String() string {
+	bs, _ := json.Marshal(s)
+	return string(bs)
+}
+
+// NewInstalmentSchedule instantiate a new instalment schedule object, set its Instalments or Plan before creating it
+func NewInstalmentSchedule(name string, totalAmount int, currency, mandateID string) *InstalmentSchedule {
+	return &InstalmentSchedule{
+		Name:        name,
+		TotalAmount: totalAmount,
+		Currency:    currency,
+		Links:       instalmentScheduleLinks{MandateID: mandateID},
+	}
+}
+
+// AddMetadata adds new metadata item to instalment schedule object
+func (s *InstalmentSchedule) AddMetadata(key, value string) {
+	if s.Metadata == nil {
+		s.Metadata = make(map[string]string)
+	}
+	s.Metadata[key] = value
+}
+
+// Validate checks that exactly one of Instalments and Plan is set and that the amounts
+// of the instalments add up to TotalAmount, which the API would otherwise reject.
+// The error wraps ErrInstalmentAmountsMismatch when the amounts do not add up.
+func (s *InstalmentSchedule) Validate() error {
+	if s == nil {
+		return fmt.Errorf("%w: an instalment schedule is required", ErrInvalidParams)
+	}
+	if (len(s.Instalments) == 0) == (s.Plan == nil) {
+		return fmt.Errorf("%w: exactly one of instalments and plan must be set", ErrInvalidParams)
+	}
+
+	total := 0
+	if s.Plan != nil {
+		for _, amount := range s.Plan.Amounts {
+			total += amount
+		}
+	}
+	for i, instalment := range s.Instalments {
+		if instalment == nil {
+			return fmt.Errorf("%w: instalment %d is nil", ErrInvalidParams, i)
+		}
+		total += instalment.Amount
+	}
+	if total != s.TotalAmount {
+		return fmt.Errorf("%w: instalments add up to %d, total_amount is %d", ErrInstalmentAmountsMismatch, total, s.TotalAmount)
+	}
+	return nil
+}
+
+func (p *InstalmentScheduleListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+	if len(p.Status) > maxInstalmentScheduleStatusFilters {
+		return nil, fmt.Errorf("%w: at most %d statuses can be used", ErrInvalidParams, maxInstalmentScheduleStatusFilters)
+	}
+
+	setValue(v, "customer", p.Customer)
+	setValue(v, "mandate", p.Mandate)
+	setValue(v, "status", strings.Join(p.Status, ","))
+	return v, nil
+}
+
+// CreateInstalmentSchedule creates a new instalment schedule object, with the payments of
+// either schedule.Instalments or schedule.Plan. The schedule is checked with Validate before
+// it is sent. Its payments are created asynchronously, its Status is "pending" until then.
+//
+// Relative endpoint: POST /instalment_schedules
+func (c *Client) CreateInstalmentSchedule(schedule *InstalmentSchedule) error {
+	return c.CreateInstalmentScheduleContext(context.Background(), schedule)
+}
+
+// CreateInstalmentScheduleContext is the same as CreateInstalmentSchedule, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateInstalmentScheduleContext(ctx context.Context, schedule *InstalmentSchedule, opts ...RequestOption) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+
+	req := &instalmentScheduleRequest{InstalmentSchedule: schedule}
+	if schedule.Plan != nil {
+		req.Instalments = schedule.Plan
+	} else {
+		req.Instalments = schedule.Instalments
+	}
+	scheduleReq := map[string]interface{}{
+		"instalment_schedules": req,
+	}
+
+	scheduleRes := &instalmentScheduleWrapper{schedule}
+
+	err := c.create(ctx, instalmentScheduleEndpoint, scheduleReq, scheduleRes, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetInstalmentSchedules returns a cursor-paginated list of your instalment schedules.
+//
+// Relative endpoint: GET /instalment_schedules
+func (c *Client) GetInstalmentSchedules(params *InstalmentScheduleListParams) (*InstalmentScheduleListResponse, error) {
+	return c.GetInstalmentSchedulesContext(context.Background(), params)
+}
+
+// GetInstalmentSchedulesContext is the same as GetInstalmentSchedules, but uses ctx for the request and applies opts to it.
+func (c *Client) GetInstalmentSchedulesContext(ctx context.Context, params *InstalmentScheduleListParams, opts ...RequestOption) (*InstalmentScheduleListResponse, error) {
+	if params == nil {
+		params = &InstalmentScheduleListParams{}
+	}
+	path, err := listPath(instalmentScheduleEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &InstalmentScheduleListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateInstalmentSchedules returns an iterator over all your instalment schedules, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateInstalmentSchedules(params *InstalmentScheduleListParams) *InstalmentScheduleIterator {
+	return c.IterateInstalmentSchedulesContext(context.Background(), params)
+}
+
+// IterateInstalmentSchedulesContext is the same as IterateInstalmentSchedules, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateInstalmentSchedulesContext(ctx context.Context, params *InstalmentScheduleListParams, opts ...RequestOption) *InstalmentScheduleIterator {
+	if params == nil {
+		params = &InstalmentScheduleListParams{}
+	}
+	p := *params
+
+	return &InstalmentScheduleIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetInstalmentSchedulesContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.InstalmentSchedules))
+		for i, v := range list.InstalmentSchedules {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// InstalmentSchedule returns the instalment schedule the iterator advanced to
+func (it *InstalmentScheduleIterator) InstalmentSchedule() *InstalmentSchedule {
+	v, _ := it.Current().(*InstalmentSchedule)
+	return v
+}
+
+// GetInstalmentSchedule retrieves the details of an existing instalment schedule.
+//
+// Relative endpoint: GET /instalment_schedules/IS123
+func (c *Client) GetInstalmentSchedule(id string) (*InstalmentSchedule, error) {
+	return c.GetInstalmentScheduleContext(context.Background(), id)
+}
+
+// GetInstalmentScheduleContext is the same as GetInstalmentSchedule, but uses ctx for the request and applies opts to it.
+func (c *Client) GetInstalmentScheduleContext(ctx context.Context, id string, opts ...RequestOption) (*InstalmentSchedule, error) {
+	wrapper := &instalmentScheduleWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, instalmentScheduleEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.InstalmentSchedule, err
+}
+
+// UpdateInstalmentSchedule Updates an instalment schedule object. Only the metadata parameter is allowed.
+//
+// Relative endpoint: PUT /instalment_schedules/IS123
+func (c *Client) UpdateInstalmentSchedule(schedule *InstalmentSchedule) error {
+	return c.UpdateInstalmentScheduleContext(context.Background(), schedule)
+}
+
+// UpdateInstalmentScheduleContext is the same as UpdateInstalmentSchedule, but uses ctx for the request and applies opts to it.
+func (c *Client) UpdateInstalmentScheduleContext(ctx context.Context, schedule *InstalmentSchedule, opts ...RequestOption) error {
+	// allows only metadata
+	scheduleMeta := map[string]interface{}{
+		"instalment_schedules": map[string]interface{}{
+			"metadata": schedule.Metadata,
+		},
+	}
+
+	scheduleReq := &instalmentScheduleWrapper{schedule}
+
+	err := c.put(ctx, fmt.Sprintf(`%s/%s`, instalmentScheduleEndpoint, schedule.ID), scheduleMeta, scheduleReq, opts...)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// CancelInstalmentSchedule immediately cancels an instalment schedule, its pending payments
+// are cancelled too.
+//
+// Relative endpoint: POST /instalment_schedules/IS123/actions/cancel
+func (c *Client) CancelInstalmentSchedule(id string) (*InstalmentSchedule, error) {
+	return c.CancelInstalmentScheduleContext(context.Background(), id)
+}
+
+// CancelInstalmentScheduleContext is the same as CancelInstalmentSchedule, but uses ctx for the request and applies opts to it.
+func (c *Client) CancelInstalmentScheduleContext(ctx context.Context, id string, opts ...RequestOption) (*InstalmentSchedule, error) {
+	wrapper := &instalmentScheduleWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/cancel`, instalmentScheduleEndpoint, id), nil, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.InstalmentSchedule, err
+}
+
+// GetInstalmentSchedulePayments retrieves the payments created by an instalment schedule,
+// in the order of schedule.Links.PaymentIDs.
+//
+// Relative endpoint: GET /payments/PM123, for each payment
+func (c *Client) GetInstalmentSchedulePayments(schedule *InstalmentSchedule) ([]*Payment, error) {
+	return c.GetInstalmentSchedulePaymentsContext(context.Background(), schedule)
+}
+
+// GetInstalmentSchedulePaymentsContext is the same as GetInstalmentSchedulePayments, but uses ctx for the requests and applies opts to them.
+func (c *Client) GetInstalmentSchedulePaymentsContext(ctx context.Context, schedule *InstalmentSchedule, opts ...RequestOption) ([]*Payment, error) {
+	payments := make([]*Payment, 0, len(schedule.Links.PaymentIDs))
+	for _, id := range schedule.Links.PaymentIDs {
+		payment, err := c.GetPaymentContext(ctx, id, opts...)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+	return payments, nil
+}
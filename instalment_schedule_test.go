@@ -0,0 +1,75 @@
+package gocardless
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestInstalmentScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		instalments []*Instalment
+		plan        *InstalmentPlan
+		err         error
+	}{
+		{name: "instalments", instalments: []*Instalment{{Amount: 600}, {Amount: 400}}},
+		{name: "plan", plan: &InstalmentPlan{Amounts: []int{500, 500}, Interval: 1, IntervalUnit: "monthly"}},
+		{name: "instalments mismatch", instalments: []*Instalment{{Amount: 600}, {Amount: 500}}, err: ErrInstalmentAmountsMismatch},
+		{name: "plan mismatch", plan: &InstalmentPlan{Amounts: []int{500}}, err: ErrInstalmentAmountsMismatch},
+		{name: "neither", err: ErrInvalidParams},
+		{name: "nil instalment", instalments: []*Instalment{{Amount: 1000}, nil}, err: ErrInvalidParams},
+		{name: "both", instalments: []*Instalment{{Amount: 1000}}, plan: &InstalmentPlan{Amounts: []int{1000}}, err: ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := NewInstalmentSchedule("Bike", 1000, "GBP", "MD123")
+			schedule.Instalments = tt.instalments
+			schedule.Plan = tt.plan
+
+			err := schedule.Validate()
+			if tt.err == nil && err != nil {
+				t.Fatal(err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestCreateInstalmentScheduleInvalid(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	defer srv.Close()
+
+	schedule := NewInstalmentSchedule("Bike", 1000, "GBP", "MD123")
+	schedule.Instalments = []*Instalment{{Amount: 999}}
+	if err := c.CreateInstalmentSchedule(schedule); !errors.Is(err, ErrInstalmentAmountsMismatch) {
+		t.Fatalf("expected ErrInstalmentAmountsMismatch, got %v", err)
+	}
+	if err := c.CreateInstalmentSchedule(nil); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("expected ErrInvalidParams, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no request, got %d", calls)
+	}
+}
+
+func TestEventLinkedInstalmentSchedules(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"events":[{"id":"EV123","links":{"instalment_schedule":"IS123"}}],` +
+			`"linked":{"instalment_schedules":[{"id":"IS123","status":"active"}]},"meta":{"cursors":{},"limit":50}}`))
+	})
+	defer srv.Close()
+
+	list, err := c.GetEvents(&EventListParams{ResourceType: EventResourceInstalmentSchedules, Include: "instalment_schedule"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Linked.InstalmentSchedules) != 1 || list.Linked.InstalmentSchedules[0].ID != "IS123" {
+		t.Errorf("unexpected linked instalment schedules %v", list.Linked.InstalmentSchedules)
+	}
+}
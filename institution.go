@@ -0,0 +1,109 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+const (
+	institutionEndpoint = "institutions"
+)
+
+type (
+	// Institution Institutions that are supported when creating a bank authorisation for a
+	// billing request, i.e. the banks the payer can pick from in instant bank pay flows
+	Institution struct {
+		// ID is a unique identifier, e.g. "MONZO_MONZGB2L"
+		ID string `json:"id"`
+		// AutocompletesCollectBankAccount whether the bank account details are collected from the
+		// institution, completing the collect_bank_account action of the billing request
+		AutocompletesCollectBankAccount bool `json:"autocompletes_collect_bank_account,omitempty"`
+		// CountryCode is the ISO 3166-1 alpha-2 code of the country the institution operates in
+		CountryCode string `json:"country_code"`
+		// IconURL A URL pointing to the icon for this institution
+		IconURL string `json:"icon_url,omitempty"`
+		// LogoURL A URL pointing to the logo for this institution
+		LogoURL string `json:"logo_url,omitempty"`
+		// Name A human readable name for this institution
+		Name string `json:"name"`
+		// Status whether the institution is "enabled", "disabled" or "temporarily_disabled"
+		Status string `json:"status,omitempty"`
+	}
+
+	// InstitutionListParams parameters for listing institutions, nil lists all of them
+	InstitutionListParams struct {
+		// CountryCode ISO 3166-1 alpha-2 code, limits to the institutions of the country
+		CountryCode string
+	}
+
+	// InstitutionListResponse a List response of Institution instances, institutions are not paginated
+	InstitutionListResponse struct {
+		Institutions []*Institution `json:"institutions"`
+	}
+)
+
+func (i *Institution) String() string {
+	bs, _ := json.Marshal(i)
+	return string(bs)
+}
+
+func (p *InstitutionListParams) values() (url.Values, error) {
+	v := url.Values{}
+	setValue(v, "country_code", p.CountryCode)
+	return v, nil
+}
+
+// GetInstitutions returns the list of the supported institutions.
+//
+// Relative endpoint: GET /institutions
+func (c *Client) GetInstitutions(params *InstitutionListParams) (*InstitutionListResponse, error) {
+	return c.GetInstitutionsContext(context.Background(), params)
+}
+
+// GetInstitutionsContext is the same as GetInstitutions, but uses ctx for the request and applies opts to it.
+func (c *Client) GetInstitutionsContext(ctx context.Context, params *InstitutionListParams, opts ...RequestOption) (*InstitutionListResponse, error) {
+	if params == nil {
+		params = &InstitutionListParams{}
+	}
+	path, err := listPath(institutionEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &InstitutionListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// GetBillingRequestInstitutions returns the institutions the payer of a billing request can
+// authorise it with, e.g. to build a bank picker. params.CountryCode is required.
+//
+// Relative endpoint: GET /billing_requests/BRQ123/institutions
+func (c *Client) GetBillingRequestInstitutions(billingRequestID string, params *InstitutionListParams) (*InstitutionListResponse, error) {
+	return c.GetBillingRequestInstitutionsContext(context.Background(), billingRequestID, params)
+}
+
+// GetBillingRequestInstitutionsContext is the same as GetBillingRequestInstitutions, but uses ctx for the request and applies opts to it.
+func (c *Client) GetBillingRequestInstitutionsContext(ctx context.Context, billingRequestID string, params *InstitutionListParams, opts ...RequestOption) (*InstitutionListResponse, error) {
+	if params == nil || params.CountryCode == "" {
+		return nil, fmt.Errorf("%w: country_code is required", ErrInvalidParams)
+	}
+	path, err := listPath(fmt.Sprintf(`%s/%s/%s`, billingRequestEndpoint, billingRequestID, institutionEndpoint), params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &InstitutionListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
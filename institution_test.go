@@ -0,0 +1,75 @@
+package gocardless
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestGetInstitutions(t *testing.T) {
+	tests := []struct {
+		name   string
+		params *InstitutionListParams
+		query  string
+	}{
+		{name: "all", params: nil, query: ""},
+		{name: "by country", params: &InstitutionListParams{CountryCode: "GB"}, query: "country_code=GB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/institutions" || r.URL.RawQuery != tt.query {
+					t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+				}
+				w.Write([]byte(`{"institutions":[{"id":"MONZO_MONZGB2L","name":"Monzo","country_code":"GB","autocompletes_collect_bank_account":true}]}`))
+			})
+			defer srv.Close()
+
+			res, err := c.GetInstitutions(tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Institutions) != 1 {
+				t.Fatalf("expected 1 institution, got %d", len(res.Institutions))
+			}
+			if i := res.Institutions[0]; i.ID != "MONZO_MONZGB2L" || i.CountryCode != "GB" || !i.AutocompletesCollectBankAccount {
+				t.Errorf("unexpected institution %v", i)
+			}
+		})
+	}
+}
+
+func TestGetBillingRequestInstitutions(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billing_requests/BRQ123/institutions" || r.URL.RawQuery != "country_code=GB" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"institutions":[{"id":"MONZO_MONZGB2L","name":"Monzo","country_code":"GB"}]}`))
+	})
+	defer srv.Close()
+
+	res, err := c.GetBillingRequestInstitutions("BRQ123", &InstitutionListParams{CountryCode: "GB"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Institutions) != 1 || res.Institutions[0].Name != "Monzo" {
+		t.Errorf("unexpected institutions %v", res.Institutions)
+	}
+}
+
+func TestGetBillingRequestInstitutionsRequiresCountry(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	defer srv.Close()
+
+	for _, params := range []*InstitutionListParams{nil, {}} {
+		if _, err := c.GetBillingRequestInstitutions("BRQ123", params); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("expected ErrInvalidParams, got %v", err)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("expected no request, got %d", calls)
+	}
+}
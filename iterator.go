@@ -0,0 +1,88 @@
+package gocardless
+
+import (
+	"context"
+)
+
+// pageFunc fetches the page of a list endpoint described by params
+type pageFunc func(ctx context.Context, params ListParams) ([]interface{}, Meta, error)
+
+// Iter lazily walks every page of a list endpoint, following Meta.Cursors.After
+// until the last page. It is embedded in the iterators of each resource, e.g.
+// PaymentIterator, which provide typed access to the current object.
+//
+//	it := client.IteratePayments(nil)
+//	for it.Next() {
+//		fmt.Println(it.Payment())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iter struct {
+	ctx    context.Context
+	fetch  pageFunc
+	params ListParams
+	items  []interface{}
+	cur    interface{}
+	meta   Meta
+	err    error
+	done   bool
+}
+
+func newIter(ctx context.Context, params ListParams, fetch pageFunc) *Iter {
+	return &Iter{ctx: ctx, params: params, fetch: fetch}
+}
+
+// Next advances to the next object, fetching the next page when the current one
+// is exhausted. It returns false at the end of the list, when ctx is done or
+// when a request failed, check Err to tell them apart.
+func (it *Iter) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.done {
+			it.cur = nil
+			return false
+		}
+
+		items, meta, err := it.fetch(it.ctx, it.params)
+		if err != nil {
+			it.err = err
+			it.cur = nil
+			return false
+		}
+
+		it.items, it.meta = items, meta
+		if meta.HasNext() {
+			it.params.Before = ""
+			it.params.After = meta.Cursors.After
+		} else {
+			it.done = true
+		}
+	}
+
+	it.cur = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Current returns the object Next advanced to
+func (it *Iter) Current() interface{} {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *Iter) Err() error {
+	return it.err
+}
+
+// Meta returns the pagination metadata of the last page fetched
+func (it *Iter) Meta() Meta {
+	return it.meta
+}
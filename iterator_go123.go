@@ -0,0 +1,109 @@
+//go:build go1.23
+// +build go1.23
+
+package gocardless
+
+import (
+	"iter"
+)
+
+// seq adapts it to a range-over-func iterator. Iteration stops at the first
+// error, which is yielded along with the zero value of T.
+func seq[T any](it *Iter) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			v, _ := it.Current().(T)
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// All returns a range-over-func iterator over the remaining customers
+//
+//	for cm, err := range client.IterateCustomers(nil).All() {
+//		...
+//	}
+func (it *CustomerIterator) All() iter.Seq2[*Customer, error] {
+	return seq[*Customer](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining bank accounts
+func (it *CustomerBankAccountIterator) All() iter.Seq2[*CustomerBankAccount, error] {
+	return seq[*CustomerBankAccount](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining mandates
+func (it *MandateIterator) All() iter.Seq2[*Mandate, error] {
+	return seq[*Mandate](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining payments
+func (it *PaymentIterator) All() iter.Seq2[*Payment, error] {
+	return seq[*Payment](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining subscriptions
+func (it *SubscriptionIterator) All() iter.Seq2[*Subscription, error] {
+	return seq[*Subscription](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining refunds
+func (it *RefundIterator) All() iter.Seq2[*Refund, error] {
+	return seq[*Refund](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining payouts
+func (it *PayoutIterator) All() iter.Seq2[*Payout, error] {
+	return seq[*Payout](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining payout items
+func (it *PayoutItemIterator) All() iter.Seq2[*PayoutItem, error] {
+	return seq[*PayoutItem](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining events
+func (it *EventIterator) All() iter.Seq2[*Event, error] {
+	return seq[*Event](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining creditors
+func (it *CreditorIterator) All() iter.Seq2[*Creditor, error] {
+	return seq[*Creditor](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining creditor bank accounts
+func (it *CreditorBankAccountIterator) All() iter.Seq2[*CreditorBankAccount, error] {
+	return seq[*CreditorBankAccount](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining mandate import entries
+func (it *MandateImportEntryIterator) All() iter.Seq2[*MandateImportEntry, error] {
+	return seq[*MandateImportEntry](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining instalment schedules
+func (it *InstalmentScheduleIterator) All() iter.Seq2[*InstalmentSchedule, error] {
+	return seq[*InstalmentSchedule](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining billing requests
+func (it *BillingRequestIterator) All() iter.Seq2[*BillingRequest, error] {
+	return seq[*BillingRequest](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining billing request templates
+func (it *BillingRequestTemplateIterator) All() iter.Seq2[*BillingRequestTemplate, error] {
+	return seq[*BillingRequestTemplate](it.Iter)
+}
+
+// All returns a range-over-func iterator over the remaining webhooks
+func (it *WebhookIterator) All() iter.Seq2[*Webhook, error] {
+	return seq[*Webhook](it.Iter)
+}
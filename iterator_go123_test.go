@@ -0,0 +1,48 @@
+//go:build go1.23
+// +build go1.23
+
+package gocardless
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIteratorAll(t *testing.T) {
+	var queries []string
+	c, srv := newTestClient(t, pagesHandler(&queries))
+	defer srv.Close()
+
+	var ids []string
+	for payment, err := range c.IteratePayments(nil).All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, payment.ID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+	if fmt.Sprint(ids) != "[PM1 PM2 PM3]" || len(queries) != 2 {
+		t.Errorf("unexpected payments %v after %v", ids, queries)
+	}
+}
+
+func TestIteratorAllError(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	defer srv.Close()
+
+	n := 0
+	for payment, err := range c.IteratePayments(nil).All() {
+		n++
+		if err == nil || payment != nil {
+			t.Errorf("expected only the error, got %v", payment)
+		}
+	}
+	if n != 1 {
+		t.Errorf("expected the error to be yielded once, got %d", n)
+	}
+}
@@ -0,0 +1,110 @@
+package gocardless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// pagesHandler serves the payments PM1 to PM5 in pages of two, following the after cursor
+func pagesHandler(queries *[]string) http.HandlerFunc {
+	pages := map[string]string{
+		"":    `{"payments":[{"id":"PM1"},{"id":"PM2"}],"meta":{"cursors":{"after":"PM2"},"limit":2}}`,
+		"PM2": `{"payments":[{"id":"PM3"},{"id":"PM4"}],"meta":{"cursors":{"before":"PM3","after":"PM4"},"limit":2}}`,
+		"PM4": `{"payments":[{"id":"PM5"}],"meta":{"cursors":{"before":"PM5"},"limit":2}}`,
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+		page, ok := pages[r.URL.Query().Get("after")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(page))
+	}
+}
+
+func TestIteratorPages(t *testing.T) {
+	var queries []string
+	c, srv := newTestClient(t, pagesHandler(&queries))
+	defer srv.Close()
+
+	it := c.IteratePayments(&PaymentListParams{ListParams: ListParams{Limit: 2}})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Payment().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(ids) != "[PM1 PM2 PM3 PM4 PM5]" {
+		t.Errorf("unexpected payments %v", ids)
+	}
+	if fmt.Sprint(queries) != "[limit=2 after=PM2&limit=2 after=PM4&limit=2]" {
+		t.Errorf("unexpected queries %v", queries)
+	}
+	if it.Meta().HasNext() {
+		t.Errorf("expected the last page, got %+v", it.Meta())
+	}
+	if it.Next() || it.Payment() != nil {
+		t.Error("expected the iteration to stay done")
+	}
+}
+
+func TestIteratorStartsAfter(t *testing.T) {
+	var queries []string
+	c, srv := newTestClient(t, pagesHandler(&queries))
+	defer srv.Close()
+
+	it := c.IteratePayments(&PaymentListParams{ListParams: ListParams{After: "PM4"}})
+	if !it.Next() || it.Payment().ID != "PM5" {
+		t.Fatalf("expected PM5, got %v", it.Payment())
+	}
+	if it.Next() {
+		t.Error("expected the end of the list")
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	var queries []string
+	c, srv := newTestClient(t, pagesHandler(&queries))
+	defer srv.Close()
+
+	it := c.IteratePayments(&PaymentListParams{ListParams: ListParams{After: "PM9"}})
+	if it.Next() {
+		t.Fatal("expected no payment")
+	}
+	var apiErr *Error
+	if !errors.As(it.Err(), &apiErr) || apiErr.Code != http.StatusNotFound {
+		t.Errorf("expected the API error, got %v", it.Err())
+	}
+	if it.Next() || len(queries) != 1 {
+		t.Errorf("expected the iteration to stop at the error, sent %v", queries)
+	}
+}
+
+func TestIteratorCancel(t *testing.T) {
+	var queries []string
+	c, srv := newTestClient(t, pagesHandler(&queries))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.IteratePaymentsContext(ctx, nil)
+	if !it.Next() {
+		t.Fatal(it.Err())
+	}
+	cancel()
+
+	if it.Next() {
+		t.Error("expected the iteration to stop once cancelled")
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", it.Err())
+	}
+	if len(queries) != 1 {
+		t.Errorf("expected a single page to be fetched, sent %v", queries)
+	}
+}
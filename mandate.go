@@ -1,13 +1,18 @@
 package gocardless
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 )
 
 const (
 	mandateEndpoint = "mandates"
+	// maxMandateStatusFilters is the number of statuses mandates can be filtered by at once
+	maxMandateStatusFilters = 4
 )
 
 type (
@@ -47,11 +52,38 @@ type (
 		Mandate *Mandate `json:"mandates"`
 	}
 
+	// MandateListParams parameters for listing mandates, nil lists the first page
+	MandateListParams struct {
+		ListParams
+		// CreatedAt limits to mandates created within the range
+		CreatedAt TimeRange
+		// Creditor ID of a creditor to filter mandates by.
+		// Cannot be used together with Customer or CustomerBankAccount
+		Creditor string
+		// Customer ID of a customer to filter mandates by.
+		// Cannot be used together with Creditor or CustomerBankAccount
+		Customer string
+		// CustomerBankAccount ID of a customer bank account to filter mandates by.
+		// Cannot be used together with Creditor or Customer
+		CustomerBankAccount string
+		// Reference mandate reference to filter by
+		Reference string
+		// Scheme Direct Debit scheme to filter mandates by, e.g. "bacs"
+		Scheme string
+		// Status at most four mandate statuses to filter by, e.g. "active"
+		Status []string
+	}
+
 	// MandateListResponse a List response of Mandate instances
 	MandateListResponse struct {
 		Mandates []*Mandate `json:"mandates"`
 		Meta     Meta       `json:"meta,omitempty"`
 	}
+
+	// MandateIterator iterates over mandates, see Client.IterateMandates
+	MandateIterator struct {
+		*Iter
+	}
 )
 
 func (m *Mandate) String() string {
@@ -71,13 +103,49 @@ func (m *Mandate) AddMetadata(key, value string) {
 	m.Metadata[key] = value
 }
 
+func (p *MandateListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+
+	links := 0
+	for _, id := range []string{p.Creditor, p.Customer, p.CustomerBankAccount} {
+		if id != "" {
+			links++
+		}
+	}
+	if links > 1 {
+		return nil, fmt.Errorf("%w: only one of creditor, customer and customer_bank_account can be used", ErrInvalidParams)
+	}
+	if len(p.Status) > maxMandateStatusFilters {
+		return nil, fmt.Errorf("%w: at most %d statuses can be used", ErrInvalidParams, maxMandateStatusFilters)
+	}
+
+	setValue(v, "creditor", p.Creditor)
+	setValue(v, "customer", p.Customer)
+	setValue(v, "customer_bank_account", p.CustomerBankAccount)
+	setValue(v, "reference", p.Reference)
+	setValue(v, "scheme", p.Scheme)
+	setValue(v, "status", strings.Join(p.Status, ","))
+	return v, nil
+}
+
 // CreateMandate creates a new mandate object.
 //
 // Relative endpoint: POST /mandates
 func (c *Client) CreateMandate(mandate *Mandate) error {
+	return c.CreateMandateContext(context.Background(), mandate)
+}
+
+// CreateMandateContext is the same as CreateMandate, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateMandateContext(ctx context.Context, mandate *Mandate, opts ...RequestOption) error {
 	mandateReq := &mandateWrapper{mandate}
 
-	err := c.post(mandateEndpoint, mandateReq, mandateReq)
+	err := c.create(ctx, mandateEndpoint, mandateReq, mandateReq, opts...)
 	if err != nil {
 		return err
 	}
@@ -88,23 +156,76 @@ func (c *Client) CreateMandate(mandate *Mandate) error {
 // GetMandates returns a cursor-paginated list of your mandates.
 //
 // Relative endpoint: GET /mandates
-func (c *Client) GetMandates() (*MandateListResponse, error) {
+func (c *Client) GetMandates(params *MandateListParams) (*MandateListResponse, error) {
+	return c.GetMandatesContext(context.Background(), params)
+}
+
+// GetMandatesContext is the same as GetMandates, but uses ctx for the request and applies opts to it.
+func (c *Client) GetMandatesContext(ctx context.Context, params *MandateListParams, opts ...RequestOption) (*MandateListResponse, error) {
+	if params == nil {
+		params = &MandateListParams{}
+	}
+	path, err := listPath(mandateEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
 	list := &MandateListResponse{}
 
-	err := c.get(mandateEndpoint, list)
+	err = c.get(ctx, path, list, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return list, err
 }
 
+// IterateMandates returns an iterator over all your mandates, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateMandates(params *MandateListParams) *MandateIterator {
+	return c.IterateMandatesContext(context.Background(), params)
+}
+
+// IterateMandatesContext is the same as IterateMandates, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateMandatesContext(ctx context.Context, params *MandateListParams, opts ...RequestOption) *MandateIterator {
+	if params == nil {
+		params = &MandateListParams{}
+	}
+	p := *params
+
+	return &MandateIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetMandatesContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.Mandates))
+		for i, v := range list.Mandates {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// Mandate returns the mandate the iterator advanced to
+func (it *MandateIterator) Mandate() *Mandate {
+	v, _ := it.Current().(*Mandate)
+	return v
+}
+
 // GetMandate retrieves the details of an existing mandate.
 //
 // Relative endpoint: GET /mandates/MD123
 func (c *Client) GetMandate(id string) (*Mandate, error) {
+	return c.GetMandateContext(context.Background(), id)
+}
+
+// GetMandateContext is the same as GetMandate, but uses ctx for the request and applies opts to it.
+func (c *Client) GetMandateContext(ctx context.Context, id string, opts ...RequestOption) (*Mandate, error) {
 	wrapper := &mandateWrapper{}
 
-	err := c.get(fmt.Sprintf(`%s/%s`, mandateEndpoint, id), wrapper)
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, mandateEndpoint, id), wrapper, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +236,11 @@ func (c *Client) GetMandate(id string) (*Mandate, error) {
 //
 // Relative endpoint: PUT /mandates/MD123
 func (c *Client) UpdateMandate(mandate *Mandate) error {
+	return c.UpdateMandateContext(context.Background(), mandate)
+}
+
+// UpdateMandateContext is the same as UpdateMandate, but uses ctx for the request and applies opts to it.
+func (c *Client) UpdateMandateContext(ctx context.Context, mandate *Mandate, opts ...RequestOption) error {
 	// allows only metadata
 	mdMeta := map[string]interface{}{
 		"mandates": map[string]interface{}{
@@ -124,7 +250,7 @@ func (c *Client) UpdateMandate(mandate *Mandate) error {
 
 	mandateReq := &mandateWrapper{mandate}
 
-	err := c.put(fmt.Sprintf(`%s/%s`, mandateEndpoint, mandate.ID), mdMeta, mandateReq)
+	err := c.put(ctx, fmt.Sprintf(`%s/%s`, mandateEndpoint, mandate.ID), mdMeta, mandateReq, opts...)
 	if err != nil {
 		return err
 	}
@@ -135,8 +261,13 @@ func (c *Client) UpdateMandate(mandate *Mandate) error {
 //
 // Relative endpoint: POST /mandates/MD123/actions/cancel
 func (c *Client) CancelMandate(id string) (*Mandate, error) {
+	return c.CancelMandateContext(context.Background(), id)
+}
+
+// CancelMandateContext is the same as CancelMandate, but uses ctx for the request and applies opts to it.
+func (c *Client) CancelMandateContext(ctx context.Context, id string, opts ...RequestOption) (*Mandate, error) {
 	wrapper := &mandateWrapper{}
-	err := c.post(fmt.Sprintf(`%s/%s/actions/cancel`, mandateEndpoint, id), nil, wrapper)
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/cancel`, mandateEndpoint, id), nil, wrapper, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -147,8 +278,13 @@ func (c *Client) CancelMandate(id string) (*Mandate, error) {
 //
 // Relative endpoint: POST /mandates/MD123/actions/reinstate
 func (c *Client) ReinstateMandate(id string) (*Mandate, error) {
+	return c.ReinstateMandateContext(context.Background(), id)
+}
+
+// ReinstateMandateContext is the same as ReinstateMandate, but uses ctx for the request and applies opts to it.
+func (c *Client) ReinstateMandateContext(ctx context.Context, id string, opts ...RequestOption) (*Mandate, error) {
 	wrapper := &mandateWrapper{}
-	err := c.post(fmt.Sprintf(`%s/%s/actions/reinstate`, mandateEndpoint, id), nil, wrapper)
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/reinstate`, mandateEndpoint, id), nil, wrapper, opts...)
 	if err != nil {
 		return nil, err
 	}
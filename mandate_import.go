@@ -0,0 +1,408 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	mandateImportEndpoint      = "mandate_imports"
+	mandateImportEntryEndpoint = "mandate_import_entries"
+
+	// mandateImportCancelTimeout bounds the cancellation of a failed import by ImportMandates
+	mandateImportCancelTimeout = 30 * time.Second
+)
+
+// Statuses of mandate imports, see MandateImport.Status
+const (
+	MandateImportStatusCreated    = "created"
+	MandateImportStatusSubmitted  = "submitted"
+	MandateImportStatusCancelled  = "cancelled"
+	MandateImportStatusProcessing = "processing"
+	MandateImportStatusProcessed  = "processed"
+)
+
+type (
+	// MandateImport Mandate Imports allow you to migrate existing mandates from other providers into
+	// GoCardless. Create an import, add an entry for each mandate, then submit it. Once GoCardless
+	// has processed the import, the entries link to the created customers, bank accounts and mandates.
+	MandateImport struct {
+		// ID is a unique identifier, beginning with "IM".
+		ID string `json:"id,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the mandate import was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Scheme The Direct Debit scheme of the imported mandates, e.g. "bacs" or "sepa_core"
+		Scheme string `json:"scheme"`
+		// Status status of mandate import, one of the MandateImportStatus constants
+		Status string `json:"status,omitempty"`
+		// Links links to the creditor the mandates are imported for
+		Links mandateImportLinks `json:"links"`
+	}
+	mandateImportLinks struct {
+		CreditorID string `json:"creditor,omitempty"`
+	}
+	// mandateImportWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	mandateImportWrapper struct {
+		MandateImport *MandateImport `json:"mandate_imports"`
+	}
+
+	// MandateImportEntry a mandate to import, with the details of its customer and bank account
+	MandateImportEntry struct {
+		// RecordIdentifier A unique identifier for this entry, which you can use to match it
+		// with the resources it creates
+		RecordIdentifier string `json:"record_identifier,omitempty"`
+		// Amendment details of the mandate at the previous provider, for schemes which require them
+		Amendment *MandateImportAmendment `json:"amendment,omitempty"`
+		// BankAccount the bank account of the customer
+		BankAccount *MandateImportBankAccount `json:"bank_account"`
+		// CreatedAt is a fixed timestamp, recording when the entry was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Customer the customer the mandate belongs to
+		Customer *MandateImportCustomer `json:"customer"`
+		// Mandate reference and metadata of the mandate to create
+		Mandate *MandateImportMandate `json:"mandate,omitempty"`
+		// ProcessingErrors why the entry could not be imported, keyed by field
+		ProcessingErrors map[string]string `json:"processing_errors,omitempty"`
+		// Links links to the import, and once processed to the created customer, bank account and mandate
+		Links mandateImportEntryLinks `json:"links"`
+	}
+	// MandateImportAmendment details of a mandate at the provider it is migrated from
+	MandateImportAmendment struct {
+		OriginalCreditorID       string `json:"original_creditor_id"`
+		OriginalCreditorName     string `json:"original_creditor_name"`
+		OriginalMandateReference string `json:"original_mandate_reference"`
+	}
+	// MandateImportBankAccount bank details of an imported mandate, either the local
+	// details or an IBAN
+	MandateImportBankAccount struct {
+		AccountHolderName string `json:"account_holder_name"`
+		AccountNumber     string `json:"account_number,omitempty"`
+		AccountType       string `json:"account_type,omitempty"`
+		BankCode          string `json:"bank_code,omitempty"`
+		BranchCode        string `json:"branch_code,omitempty"`
+		CountryCode       string `json:"country_code,omitempty"`
+		IBAN              string `json:"iban,omitempty"`
+	}
+	// MandateImportCustomer contact details of the customer of an imported mandate
+	MandateImportCustomer struct {
+		AddressLine1          string `json:"address_line1,omitempty"`
+		AddressLine2          string `json:"address_line2,omitempty"`
+		AddressLine3          string `json:"address_line3,omitempty"`
+		City                  string `json:"city,omitempty"`
+		CompanyName           string `json:"company_name,omitempty"`
+		CountryCode           string `json:"country_code,omitempty"`
+		DanishIdentityNumber  string `json:"danish_identity_number,omitempty"`
+		Email                 string `json:"email"`
+		FamilyName            string `json:"family_name,omitempty"`
+		GivenName             string `json:"given_name,omitempty"`
+		Language              string `json:"language,omitempty"`
+		PhoneNumber           string `json:"phone_number,omitempty"`
+		PostalCode            string `json:"postal_code,omitempty"`
+		Region                string `json:"region,omitempty"`
+		SwedishIdentityNumber string `json:"swedish_identity_number,omitempty"`
+	}
+	// MandateImportMandate reference and metadata of the mandate created by an entry
+	MandateImportMandate struct {
+		Metadata  map[string]string `json:"metadata,omitempty"`
+		Reference string            `json:"reference,omitempty"`
+	}
+	mandateImportEntryLinks struct {
+		CustomerID            string `json:"customer,omitempty"`
+		CustomerBankAccountID string `json:"customer_bank_account,omitempty"`
+		MandateID             string `json:"mandate,omitempty"`
+		MandateImportID       string `json:"mandate_import"`
+	}
+	// mandateImportEntryWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	mandateImportEntryWrapper struct {
+		MandateImportEntry *MandateImportEntry `json:"mandate_import_entries"`
+	}
+
+	// MandateImportEntryListParams parameters for listing the entries of a mandate import
+	MandateImportEntryListParams struct {
+		ListParams
+		// MandateImport ID of the mandate import to list entries of, required
+		MandateImport string
+		// Status limits to "successfully_processed" or "unsuccessfully_processed" entries
+		Status string
+	}
+
+	// MandateImportEntryListResponse a List response of MandateImportEntry instances
+	MandateImportEntryListResponse struct {
+		MandateImportEntries []*MandateImportEntry `json:"mandate_import_entries"`
+		Meta                 Meta                  `json:"meta,omitempty"`
+	}
+
+	// MandateImportEntryIterator iterates over the entries of a mandate import, see Client.IterateMandateImportEntries
+	MandateImportEntryIterator struct {
+		*Iter
+	}
+
+	// MandateImportResult the outcome of adding one entry in Client.ImportMandates
+	MandateImportResult struct {
+		// Entry the entry as returned by the API, or as given when it could not be added
+		Entry *MandateImportEntry
+		// Err why the entry could not be added, nil on success
+		Err error
+	}
+)
+
+func (mi *MandateImport) String() string {
+	bs, _ := json.Marshal(mi)
+	return string(bs)
+}
+
+func (e *MandateImportEntry) String() string {
+	bs, _ := json.Marshal(e)
+	return string(bs)
+}
+
+// NewMandateImport instantiate a new mandate import object
+func NewMandateImport(scheme string) *MandateImport {
+	return &MandateImport{
+		Scheme: scheme,
+	}
+}
+
+func (p *MandateImportEntryListParams) values() (url.Values, error) {
+	if p.MandateImport == "" {
+		return nil, fmt.Errorf("%w: mandate_import is required", ErrInvalidParams)
+	}
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+
+	v.Set("mandate_import", p.MandateImport)
+	setValue(v, "status", p.Status)
+	return v, nil
+}
+
+// CreateMandateImport creates a new mandate import object, to which entries can be added.
+//
+// Relative endpoint: POST /mandate_imports
+func (c *Client) CreateMandateImport(mandateImport *MandateImport) error {
+	return c.CreateMandateImportContext(context.Background(), mandateImport)
+}
+
+// CreateMandateImportContext is the same as CreateMandateImport, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateMandateImportContext(ctx context.Context, mandateImport *MandateImport, opts ...RequestOption) error {
+	mandateImportReq := &mandateImportWrapper{mandateImport}
+
+	err := c.create(ctx, mandateImportEndpoint, mandateImportReq, mandateImportReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetMandateImport retrieves the details of an existing mandate import.
+//
+// Relative endpoint: GET /mandate_imports/IM123
+func (c *Client) GetMandateImport(id string) (*MandateImport, error) {
+	return c.GetMandateImportContext(context.Background(), id)
+}
+
+// GetMandateImportContext is the same as GetMandateImport, but uses ctx for the request and applies opts to it.
+func (c *Client) GetMandateImportContext(ctx context.Context, id string, opts ...RequestOption) (*MandateImport, error) {
+	wrapper := &mandateImportWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, mandateImportEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.MandateImport, err
+}
+
+// SubmitMandateImport submits a mandate import for processing, no more entries can be added.
+//
+// Relative endpoint: POST /mandate_imports/IM123/actions/submit
+func (c *Client) SubmitMandateImport(id string) (*MandateImport, error) {
+	return c.SubmitMandateImportContext(context.Background(), id)
+}
+
+// SubmitMandateImportContext is the same as SubmitMandateImport, but uses ctx for the request and applies opts to it.
+func (c *Client) SubmitMandateImportContext(ctx context.Context, id string, opts ...RequestOption) (*MandateImport, error) {
+	wrapper := &mandateImportWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/submit`, mandateImportEndpoint, id), nil, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.MandateImport, err
+}
+
+// CancelMandateImport cancels a mandate import which has not been submitted yet.
+//
+// Relative endpoint: POST /mandate_imports/IM123/actions/cancel
+func (c *Client) CancelMandateImport(id string) (*MandateImport, error) {
+	return c.CancelMandateImportContext(context.Background(), id)
+}
+
+// CancelMandateImportContext is the same as CancelMandateImport, but uses ctx for the request and applies opts to it.
+func (c *Client) CancelMandateImportContext(ctx context.Context, id string, opts ...RequestOption) (*MandateImport, error) {
+	wrapper := &mandateImportWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/cancel`, mandateImportEndpoint, id), nil, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.MandateImport, err
+}
+
+// CreateMandateImportEntry adds an entry to a mandate import, set in entry.Links.MandateImportID.
+//
+// Relative endpoint: POST /mandate_import_entries
+func (c *Client) CreateMandateImportEntry(entry *MandateImportEntry) error {
+	return c.CreateMandateImportEntryContext(context.Background(), entry)
+}
+
+// CreateMandateImportEntryContext is the same as CreateMandateImportEntry, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateMandateImportEntryContext(ctx context.Context, entry *MandateImportEntry, opts ...RequestOption) error {
+	entryReq := &mandateImportEntryWrapper{entry}
+
+	err := c.post(ctx, mandateImportEntryEndpoint, entryReq, entryReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetMandateImportEntries returns a cursor-paginated list of the entries of a mandate import.
+// params.MandateImport is required.
+//
+// Relative endpoint: GET /mandate_import_entries
+func (c *Client) GetMandateImportEntries(params *MandateImportEntryListParams) (*MandateImportEntryListResponse, error) {
+	return c.GetMandateImportEntriesContext(context.Background(), params)
+}
+
+// GetMandateImportEntriesContext is the same as GetMandateImportEntries, but uses ctx for the request and applies opts to it.
+func (c *Client) GetMandateImportEntriesContext(ctx context.Context, params *MandateImportEntryListParams, opts ...RequestOption) (*MandateImportEntryListResponse, error) {
+	if params == nil {
+		params = &MandateImportEntryListParams{}
+	}
+	path, err := listPath(mandateImportEntryEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &MandateImportEntryListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateMandateImportEntries returns an iterator over all the entries of a mandate import,
+// fetching pages lazily as it advances. params.MandateImport is required.
+func (c *Client) IterateMandateImportEntries(params *MandateImportEntryListParams) *MandateImportEntryIterator {
+	return c.IterateMandateImportEntriesContext(context.Background(), params)
+}
+
+// IterateMandateImportEntriesContext is the same as IterateMandateImportEntries, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateMandateImportEntriesContext(ctx context.Context, params *MandateImportEntryListParams, opts ...RequestOption) *MandateImportEntryIterator {
+	if params == nil {
+		params = &MandateImportEntryListParams{}
+	}
+	p := *params
+
+	return &MandateImportEntryIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetMandateImportEntriesContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.MandateImportEntries))
+		for i, v := range list.MandateImportEntries {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// MandateImportEntry returns the mandate import entry the iterator advanced to
+func (it *MandateImportEntryIterator) MandateImportEntry() *MandateImportEntry {
+	v, _ := it.Current().(*MandateImportEntry)
+	return v
+}
+
+// ImportMandates drives a whole mandate import: it creates mandateImport unless it already
+// has an ID, adds every entry to it and submits it. The result of each entry is returned in
+// the order of entries. When some entries could not be added the import is cancelled and
+// the error wraps ErrMandateImportEntriesFailed, fix the failed entries and import them all again.
+// mandateImport and at least one entry are required, no request is sent when they are missing.
+//
+// The created mandates are only linked from the entries once GoCardless has processed the
+// import, list them with GetMandateImportEntries.
+func (c *Client) ImportMandates(mandateImport *MandateImport, entries []*MandateImportEntry) ([]*MandateImportResult, error) {
+	return c.ImportMandatesContext(context.Background(), mandateImport, entries)
+}
+
+// ImportMandatesContext is the same as ImportMandates, but uses ctx for the requests and applies
+// opts to each of them, WithIdempotencyKey must not be used as every request needs its own key.
+// Once ctx is done no more entries are added, the import is cancelled and ctx.Err() is returned.
+func (c *Client) ImportMandatesContext(ctx context.Context, mandateImport *MandateImport, entries []*MandateImportEntry, opts ...RequestOption) ([]*MandateImportResult, error) {
+	if mandateImport == nil {
+		return nil, fmt.Errorf("%w: a mandate import is required", ErrInvalidParams)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: at least one entry is required", ErrInvalidParams)
+	}
+	for i, entry := range entries {
+		if entry == nil {
+			return nil, fmt.Errorf("%w: entry %d is nil", ErrInvalidParams, i)
+		}
+	}
+
+	if mandateImport.ID == "" {
+		if err := c.CreateMandateImportContext(ctx, mandateImport, opts...); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]*MandateImportResult, len(entries))
+	failed := 0
+	for i, entry := range entries {
+		err := ctx.Err()
+		if err == nil {
+			entry.Links.MandateImportID = mandateImport.ID
+			err = c.CreateMandateImportEntryContext(ctx, entry, opts...)
+		}
+		if err != nil {
+			failed++
+		}
+		results[i] = &MandateImportResult{Entry: entry, Err: err}
+	}
+
+	if failed > 0 {
+		// the import is cancelled even when ctx is done, so that it is not left open
+		cancelCtx, cancel := context.WithTimeout(context.Background(), mandateImportCancelTimeout)
+		defer cancel()
+
+		cancelled, err := c.CancelMandateImportContext(cancelCtx, mandateImport.ID, opts...)
+		if err != nil {
+			return results, err
+		}
+		if cancelled != nil {
+			*mandateImport = *cancelled
+		}
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		return results, fmt.Errorf("%w: %d of %d entries, import %s cancelled", ErrMandateImportEntriesFailed, failed, len(entries), mandateImport.ID)
+	}
+
+	submitted, err := c.SubmitMandateImportContext(ctx, mandateImport.ID, opts...)
+	if err != nil {
+		return results, err
+	}
+	if submitted != nil {
+		*mandateImport = *submitted
+	}
+	return results, nil
+}
@@ -0,0 +1,146 @@
+package gocardless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// mandateImportHandler serves a mandate import IM123, entries with a "bad" record identifier
+// fail validation. onEntry is called for every entry added.
+func mandateImportHandler(t *testing.T, paths *[]string, onEntry func()) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+
+		switch r.URL.Path {
+		case "/mandate_imports":
+			w.Write([]byte(`{"mandate_imports":{"id":"IM123","status":"created"}}`))
+		case "/mandate_import_entries":
+			if !strings.Contains(string(body), `"mandate_import":"IM123"`) {
+				t.Errorf("entry not linked to the import: %s", body)
+			}
+			if onEntry != nil {
+				onEntry()
+			}
+			if strings.Contains(string(body), `"bad"`) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"error":{"type":"validation_failed","code":422}}`))
+				return
+			}
+			w.Write(body)
+		case "/mandate_imports/IM123/actions/cancel":
+			w.Write([]byte(`{"mandate_imports":{"id":"IM123","status":"cancelled"}}`))
+		case "/mandate_imports/IM123/actions/submit":
+			w.Write([]byte(`{"mandate_imports":{"id":"IM123","status":"submitted"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func newTestEntry(recordIdentifier string) *MandateImportEntry {
+	return &MandateImportEntry{
+		RecordIdentifier: recordIdentifier,
+		Customer:         &MandateImportCustomer{Email: "user@example.com"},
+		BankAccount:      &MandateImportBankAccount{AccountHolderName: "Frank Osborne"},
+	}
+}
+
+func TestImportMandates(t *testing.T) {
+	var paths []string
+	c, srv := newTestClient(t, mandateImportHandler(t, &paths, nil))
+	defer srv.Close()
+
+	mandateImport := NewMandateImport("bacs")
+	results, err := c.ImportMandates(mandateImport, []*MandateImportEntry{newTestEntry("a"), newTestEntry("b")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mandateImport.Status != MandateImportStatusSubmitted {
+		t.Errorf("expected a submitted import, got %s", mandateImport.Status)
+	}
+	if len(results) != 2 || results[0].Err != nil || results[1].Entry.RecordIdentifier != "b" {
+		t.Errorf("unexpected results %v", results)
+	}
+}
+
+func TestImportMandatesFailedEntries(t *testing.T) {
+	var paths []string
+	c, srv := newTestClient(t, mandateImportHandler(t, &paths, nil))
+	defer srv.Close()
+
+	mandateImport := NewMandateImport("bacs")
+	results, err := c.ImportMandates(mandateImport, []*MandateImportEntry{newTestEntry("a"), newTestEntry("bad")})
+	if !errors.Is(err, ErrMandateImportEntriesFailed) {
+		t.Fatalf("expected ErrMandateImportEntriesFailed, got %v", err)
+	}
+	if results[0].Err != nil || results[1].Err == nil {
+		t.Errorf("unexpected results %v", results)
+	}
+	if mandateImport.Status != MandateImportStatusCancelled {
+		t.Errorf("expected a cancelled import, got %s", mandateImport.Status)
+	}
+}
+
+func TestImportMandatesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var paths []string
+	c, srv := newTestClient(t, mandateImportHandler(t, &paths, cancel))
+	defer srv.Close()
+
+	mandateImport := NewMandateImport("bacs")
+	entries := []*MandateImportEntry{newTestEntry("a"), newTestEntry("b"), newTestEntry("c")}
+	results, err := c.ImportMandatesContext(ctx, mandateImport, entries)
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if results[1].Err != context.Canceled || results[2].Err != context.Canceled {
+		t.Errorf("expected the remaining entries to be skipped, got %v", results)
+	}
+
+	want := "[/mandate_imports /mandate_import_entries /mandate_imports/IM123/actions/cancel]"
+	if got := fmt.Sprint(paths); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if mandateImport.Status != MandateImportStatusCancelled {
+		t.Errorf("expected a cancelled import, got %s", mandateImport.Status)
+	}
+}
+
+func TestImportMandatesInvalidParams(t *testing.T) {
+	var paths []string
+	c, srv := newTestClient(t, mandateImportHandler(t, &paths, nil))
+	defer srv.Close()
+
+	tests := []struct {
+		name          string
+		mandateImport *MandateImport
+		entries       []*MandateImportEntry
+	}{
+		{name: "nil import", mandateImport: nil, entries: []*MandateImportEntry{newTestEntry("a")}},
+		{name: "no entries", mandateImport: NewMandateImport("bacs"), entries: nil},
+		{name: "empty entries", mandateImport: NewMandateImport("bacs"), entries: []*MandateImportEntry{}},
+		{name: "nil entry", mandateImport: NewMandateImport("bacs"), entries: []*MandateImportEntry{newTestEntry("a"), nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := c.ImportMandates(tt.mandateImport, tt.entries)
+			if !errors.Is(err, ErrInvalidParams) {
+				t.Errorf("expected ErrInvalidParams, got %v", err)
+			}
+			if results != nil {
+				t.Errorf("expected no results, got %v", results)
+			}
+		})
+	}
+	if len(paths) != 0 {
+		t.Errorf("expected no request, got %v", paths)
+	}
+}
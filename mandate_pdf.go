@@ -0,0 +1,109 @@
+package gocardless
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	mandatePDFEndpoint = "mandate_pdfs"
+)
+
+type (
+	// MandatePDFParams describe the mandate to generate a PDF of, either an existing mandate
+	// through Mandate or a mandate yet to be created through the bank details. When Mandate
+	// is set the bank details are taken from the mandate and the other fields are ignored.
+	MandatePDFParams struct {
+		// Mandate ID of an existing mandate to build the PDF from
+		Mandate string `json:"-"`
+		// Language ISO 639-1 code of the language of the PDF, e.g. "fr". Defaults to English
+		Language string `json:"-"`
+		// AccountHolderName Name of the account holder, as known by the bank
+		AccountHolderName string `json:"account_holder_name,omitempty"`
+		// AccountNumber Bank account number. Alternatively you can provide an iban
+		AccountNumber string `json:"account_number,omitempty"`
+		// AccountType Bank account type, "savings" or "checking". Required for USD-denominated bank accounts
+		AccountType string `json:"account_type,omitempty"`
+		// AddressLine1 is the first line of the customer’s address.
+		AddressLine1 string `json:"address_line1,omitempty"`
+		// BankCode Bank code
+		BankCode string `json:"bank_code,omitempty"`
+		// BIC SWIFT BIC, will be derived automatically if a valid iban or local details are provided
+		BIC string `json:"bic,omitempty"`
+		// BranchCode Branch code
+		BranchCode string `json:"branch_code,omitempty"`
+		// City is the city of the customer’s address.
+		City string `json:"city,omitempty"`
+		// CountryCode is the ISO 3166-1 alpha-2 code.
+		CountryCode string `json:"country_code,omitempty"`
+		// DanishIdentityNumber is for Danish customers only, their CPR or CVR number
+		DanishIdentityNumber string `json:"danish_identity_number,omitempty"`
+		// IBAN International Bank Account Number
+		IBAN string `json:"iban,omitempty"`
+		// MandateReference Unique reference to show on the PDF
+		MandateReference string `json:"mandate_reference,omitempty"`
+		// PayerIPAddress the IP address the customer signed the mandate from
+		PayerIPAddress string `json:"payer_ip_address,omitempty"`
+		// PhoneNumber is the customer’s phone number, required for Becs NZ mandates
+		PhoneNumber string `json:"phone_number,omitempty"`
+		// PostalCode is the customer’s postal code
+		PostalCode string `json:"postal_code,omitempty"`
+		// Region is the customer’s address region, county or department
+		Region string `json:"region,omitempty"`
+		// Scheme Direct Debit scheme of the mandate, guessed from the bank details when not set
+		Scheme string `json:"scheme,omitempty"`
+		// SignatureDate date the mandate was signed, shown on the PDF
+		SignatureDate *Date `json:"signature_date,omitempty"`
+		// SubscriptionAmount amount of the subscription the mandate is for, shown on Autogiro PDFs
+		SubscriptionAmount int `json:"subscription_amount,omitempty"`
+		// SubscriptionFrequency frequency of the subscription the mandate is for, shown on Autogiro PDFs
+		SubscriptionFrequency string `json:"subscription_frequency,omitempty"`
+		// SwedishIdentityNumber is for Swedish customers only, their civic/company number
+		SwedishIdentityNumber string `json:"swedish_identity_number,omitempty"`
+	}
+
+	// mandatePDFRequest is a utility struct used to wrap the JSON request being passed to the remote API
+	mandatePDFRequest struct {
+		*MandatePDFParams
+		Links *mandatePDFLinks `json:"links,omitempty"`
+	}
+	mandatePDFLinks struct {
+		MandateID string `json:"mandate"`
+	}
+)
+
+// CreateMandatePDF generates a PDF of a mandate and writes it to w, for an existing mandate
+// or from bank details. w is required, including a nil pointer in a non-nil interface,
+// and either params.Mandate, params.AccountNumber or params.IBAN.
+//
+// Relative endpoint: POST /mandate_pdfs
+func (c *Client) CreateMandatePDF(w io.Writer, params *MandatePDFParams) error {
+	return c.CreateMandatePDFContext(context.Background(), w, params)
+}
+
+// CreateMandatePDFContext is the same as CreateMandatePDF, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateMandatePDFContext(ctx context.Context, w io.Writer, params *MandatePDFParams, opts ...RequestOption) error {
+	if isNil(w) {
+		return fmt.Errorf("%w: a writer is required", ErrInvalidParams)
+	}
+	if params == nil || (params.Mandate == "" && params.AccountNumber == "" && params.IBAN == "") {
+		return fmt.Errorf("%w: a mandate or bank details are required", ErrInvalidParams)
+	}
+
+	req := &mandatePDFRequest{MandatePDFParams: params}
+	if params.Mandate != "" {
+		req.MandatePDFParams = &MandatePDFParams{}
+		req.Links = &mandatePDFLinks{MandateID: params.Mandate}
+	}
+	body := map[string]interface{}{
+		"mandate_pdfs": req,
+	}
+
+	pdfOpts := []RequestOption{withRequestHeader("Accept", "application/pdf")}
+	if params.Language != "" {
+		pdfOpts = append(pdfOpts, withRequestHeader("Accept-Language", params.Language))
+	}
+
+	return c.post(ctx, mandatePDFEndpoint, body, rawBody{w}, append(pdfOpts, opts...)...)
+}
@@ -0,0 +1,91 @@
+package gocardless
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCreateMandatePDF(t *testing.T) {
+	var body map[string]map[string]interface{}
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/pdf" || r.Header.Get("Accept-Language") != "fr" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4"))
+	})
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	if err := c.CreateMandatePDF(&buf, &MandatePDFParams{Mandate: "MD123", Language: "fr"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "%PDF-1.4" {
+		t.Errorf("unexpected pdf %q", buf.String())
+	}
+	if links, _ := body["mandate_pdfs"]["links"].(map[string]interface{}); links["mandate"] != "MD123" {
+		t.Errorf("expected a link to MD123, got %v", body)
+	}
+}
+
+// writableMandate is a JSON destination which also implements io.Writer
+type writableMandate struct {
+	ID      string `json:"id"`
+	written int
+}
+
+func (w *writableMandate) Write(p []byte) (int, error) {
+	w.written += len(p)
+	return len(p), nil
+}
+
+func TestBindDecodesWriters(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"MD123"}`))
+	})
+	defer srv.Close()
+
+	var dst writableMandate
+	if err := c.get(context.Background(), "mandates/MD123", &dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.ID != "MD123" || dst.written != 0 {
+		t.Errorf("expected the body to be decoded, got %+v", dst)
+	}
+}
+
+func TestCreateMandatePDFInvalidParams(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	defer srv.Close()
+
+	var nilBuffer *bytes.Buffer
+	tests := []struct {
+		name   string
+		w      io.Writer
+		params *MandatePDFParams
+	}{
+		{name: "nil writer", w: nil, params: &MandatePDFParams{Mandate: "MD123"}},
+		{name: "typed nil writer", w: nilBuffer, params: &MandatePDFParams{Mandate: "MD123"}},
+		{name: "nil params", w: &bytes.Buffer{}, params: nil},
+		{name: "no mandate or bank details", w: &bytes.Buffer{}, params: &MandatePDFParams{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := c.CreateMandatePDF(tt.w, tt.params); !errors.Is(err, ErrInvalidParams) {
+				t.Errorf("expected ErrInvalidParams, got %v", err)
+			}
+		})
+	}
+	if calls != 0 {
+		t.Errorf("expected no request, got %d", calls)
+	}
+}
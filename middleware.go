@@ -0,0 +1,117 @@
+package gocardless
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"time"
+)
+
+// Request describes a single attempt of a request to the API, as seen by Middleware.
+// Middleware may modify it before passing it on, e.g. to add headers.
+type Request struct {
+	// Method is the HTTP method of the request
+	Method string
+	// Path is the path of the request relative to Client.RemoteURL, including the query string
+	Path string
+	// Header holds the headers sent with the request
+	Header http.Header
+	// Body is the JSON encoded request body, empty for requests without body
+	Body []byte
+	// Attempt is 1 for the first attempt and grows with every retry
+	Attempt int
+}
+
+// Handler sends a Request to the API and returns its Response
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler to run code before and after every request sent by
+// the Client, e.g. for logging, metrics or tracing. A Middleware must either return
+// a Response or an error, and must not consume the body of a successful Response.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware appends middleware to the Client's chain. The first middleware is the
+// outermost one: it sees the request first and the response last. Every retry of a
+// request goes through the whole chain again.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(c *Client) error {
+		c.middleware = append(c.middleware, middleware...)
+		return nil
+	}
+}
+
+// HeaderMiddleware sets header on every request, replacing values set by earlier middleware.
+// Unlike WithHeader it can be used to override the Client's own headers.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			for key, values := range header {
+				req.Header[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// LoggingMiddleware logs the method, path, status code and duration of every request to logger
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+			duration := time.Since(start)
+
+			if err != nil {
+				logger.Printf("gocardless: %s %s attempt=%d duration=%s error=%v", req.Method, req.Path, req.Attempt, duration, err)
+			} else {
+				logger.Printf("gocardless: %s %s attempt=%d duration=%s status=%d request_id=%s", req.Method, req.Path, req.Attempt, duration, resp.StatusCode, resp.RequestID())
+			}
+			return resp, err
+		}
+	}
+}
+
+// DebugMiddleware dumps every request and response, including headers and bodies, to w.
+// The Authorization header is redacted. It is meant for debugging only, as bodies hold
+// personal and bank details. Errors writing to w are ignored, they never fail the request.
+func DebugMiddleware(w io.Writer) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			var buf bytes.Buffer
+			fmt.Fprintf(&buf, "> %s %s (attempt %d)\n", req.Method, req.Path, req.Attempt)
+			header := req.Header.Clone()
+			if header.Get("Authorization") != "" {
+				header.Set("Authorization", "Bearer [redacted]")
+			}
+			header.Write(&buf)
+			if len(req.Body) > 0 {
+				fmt.Fprintf(&buf, "\n%s\n", req.Body)
+			}
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+			fmt.Fprintf(&buf, "< %s\n", time.Since(start))
+
+			if err != nil {
+				fmt.Fprintf(&buf, "error: %v\n", err)
+			} else {
+				// DumpResponse replaces the body with an unread copy
+				dump, derr := httputil.DumpResponse(resp.Response, true)
+				if derr != nil {
+					fmt.Fprintf(&buf, "error dumping response: %v\n", derr)
+				}
+				buf.Write(dump)
+				buf.WriteString("\n")
+			}
+
+			// a single write keeps dumps of concurrent requests apart, a failed
+			// dump is not worth failing the request for
+			_, _ = w.Write(buf.Bytes())
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package gocardless
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(ctx, req)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "server")
+		w.Write([]byte(`{"mandates":{"id":"MD123"}}`))
+	}, WithMiddleware(record("first"), record("second")), WithMiddleware(record("third")))
+	defer srv.Close()
+
+	if _, err := c.GetMandate("MD123"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"first before", "second before", "third before", "server", "third after", "second after", "first after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected %q, got %q", want, calls)
+	}
+}
+
+func TestMiddlewareRetries(t *testing.T) {
+	var attempts []int
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"mandates":{"id":"MD123"}}`))
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3}), WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			attempts = append(attempts, req.Attempt)
+			return next(ctx, req)
+		}
+	}))
+	defer srv.Close()
+
+	if _, err := c.GetMandate("MD123"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(attempts, []int{1, 2, 3}) {
+		t.Errorf("expected attempts [1 2 3], got %v", attempts)
+	}
+}
+
+func TestMiddlewareError(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}, WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			return nil, errors.New("blocked")
+		}
+	}))
+	defer srv.Close()
+
+	_, err := c.GetMandate("MD123")
+	if err == nil || err.Error() != "blocked" {
+		t.Fatalf("expected the middleware error, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no request, got %d", calls)
+	}
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	var header http.Header
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{"mandates":{"id":"MD123"}}`))
+	}, WithMiddleware(HeaderMiddleware(http.Header{
+		"x-trace-id":         {"TR123"},
+		"GoCardless-Version": {"2020-01-01"},
+	})))
+	defer srv.Close()
+
+	if _, err := c.GetMandate("MD123"); err != nil {
+		t.Fatal(err)
+	}
+	if got := header.Get("X-Trace-Id"); got != "TR123" {
+		t.Errorf("expected X-Trace-Id TR123, got %q", got)
+	}
+	if got := header["Gocardless-Version"]; !reflect.DeepEqual(got, []string{"2020-01-01"}) {
+		t.Errorf("expected the version header to be replaced, got %q", got)
+	}
+	if got := header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("expected the client's Authorization header, got %q", got)
+	}
+}
+
+func TestDebugMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "RQ123")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"customers":{"id":"CU123"}}`))
+	}, WithMiddleware(DebugMiddleware(&buf)))
+	defer srv.Close()
+
+	customer := &Customer{Email: "user@example.com"}
+	if err := c.CreateCustomer(customer); err != nil {
+		t.Fatal(err)
+	}
+	if customer.ID != "CU123" {
+		t.Errorf("expected the response body to be decoded, got id %q", customer.ID)
+	}
+
+	dump := buf.String()
+	if strings.Contains(dump, "Bearer token") {
+		t.Errorf("expected the Authorization header to be redacted, got %s", dump)
+	}
+	for _, want := range []string{"> POST customers (attempt 1)", "Authorization: Bearer [redacted]", "user@example.com", "201 Created", "X-Request-Id: RQ123", `"CU123"`} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("expected the dump to contain %q, got %s", want, dump)
+		}
+	}
+}
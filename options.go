@@ -0,0 +1,189 @@
+package gocardless
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ClientOption configures optional settings of a Client, see NewClient
+type ClientOption func(*Client) error
+
+// WithHTTPClient sets the http.Client used to send requests to the API.
+// Use it to share connection pools or to configure proxies and TLS.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) error {
+		if hc == nil {
+			return errors.New("gocardless: http client must not be nil")
+		}
+		c.httpClient = hc
+		return nil
+	}
+}
+
+// WithTransport sets the http.RoundTripper used by the Client's http.Client
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		if rt == nil {
+			return errors.New("gocardless: transport must not be nil")
+		}
+		// copy so that an http.Client passed to WithHTTPClient is left untouched
+		hc := *c.client()
+		hc.Transport = rt
+		c.httpClient = &hc
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for each request made by the Client,
+// including connection time, redirects and reading the response body.
+// A timeout of zero means no timeout.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return errors.New("gocardless: timeout must not be negative")
+		}
+		hc := *c.client()
+		hc.Timeout = timeout
+		c.httpClient = &hc
+		return nil
+	}
+}
+
+// WithRemoteURL overrides the address of the GoCardless API, e.g. to point
+// the Client at a local stand-in during tests
+func WithRemoteURL(remoteURL string) ClientOption {
+	return func(c *Client) error {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return errors.New("gocardless: remote url must be absolute")
+		}
+		if !strings.HasSuffix(remoteURL, "/") {
+			remoteURL += "/"
+		}
+		c.RemoteURL = remoteURL
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithHeader adds an extra header sent with every request. Headers set by the
+// Client itself, such as Authorization and GoCardless-Version, cannot be overridden.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) error {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Add(key, value)
+		return nil
+	}
+}
+
+// WithRetryPolicy enables automatic retries of failed requests, see RetryPolicy
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MinBackoff < 0 || policy.MaxBackoff < 0 {
+			return errors.New("gocardless: retry backoff must not be negative")
+		}
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithoutRateLimiter disables the client-side rate limiter, requests are then sent
+// even when the RateLimit-Remaining budget of the current window is exhausted
+func WithoutRateLimiter() ClientOption {
+	return func(c *Client) error {
+		c.limiter = nil
+		return nil
+	}
+}
+
+// WithConflictResolution makes Create methods return the resource created by an earlier
+// request when the API reports an idempotent creation conflict, instead of the error.
+// Combine it with WithIdempotencyKey to safely repeat a creation. Other requests,
+// such as actions, are not resolved.
+func WithConflictResolution() ClientOption {
+	return func(c *Client) error {
+		c.resolveConflicts = true
+		return nil
+	}
+}
+
+// WithBankDetailsLookup makes CreateCustomerBankAccount look the bank details up before
+// creating the bank account, invalid details then fail early with a ValidationFailedError
+// and details that no scheme can debit with a NoDebitSchemeError. Bank accounts created
+// from a customer bank account token are not looked up.
+func WithBankDetailsLookup() ClientOption {
+	return func(c *Client) error {
+		c.lookupBankDetails = true
+		return nil
+	}
+}
+
+// RequestOption configures a single request, pass it to the Context variant of a resource method
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	idempotencyKey string
+	response       *ResponseMetadata
+	// create marks the creation of a resource, see Client.create
+	create bool
+	// header holds headers specific to the endpoint, they replace the default ones
+	header http.Header
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithIdempotencyKey sets the Idempotency-Key sent when creating a resource, instead
+// of a random one. Sending the same key again returns an idempotent creation conflict,
+// see WithConflictResolution.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// withRequestHeader sets a header of a single request, replacing the default value if any
+func withRequestHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// RecordResponse stores the status code, headers, request ID and rate limit values of
+// the response into meta, for successful and failed requests alike. meta is left
+// untouched when no response was received.
+func RecordResponse(meta *ResponseMetadata) RequestOption {
+	return func(o *requestOptions) {
+		o.response = meta
+	}
+}
+
+// asCreate marks a request as the creation of a resource, see Client.create
+func asCreate() RequestOption {
+	return func(o *requestOptions) {
+		o.create = true
+	}
+}
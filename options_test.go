@@ -0,0 +1,113 @@
+package gocardless
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripperFunc adapts a function to an http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewClientEnvironment(t *testing.T) {
+	c, err := NewClient("token", LiveEnvironment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.RemoteURL != baseLiveURL {
+		t.Errorf("expected %s, got %s", baseLiveURL, c.RemoteURL)
+	}
+
+	c, err = NewClient("token", Environment("staging"))
+	if err == nil || c != nil {
+		t.Fatalf("expected an error for an unknown environment, got %v", c)
+	}
+	if !strings.Contains(err.Error(), "staging") {
+		t.Errorf("expected the environment in the error, got %v", err)
+	}
+}
+
+func TestNewClientInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  ClientOption
+	}{
+		{name: "nil http client", opt: WithHTTPClient(nil)},
+		{name: "nil transport", opt: WithTransport(nil)},
+		{name: "negative timeout", opt: WithTimeout(-time.Second)},
+		{name: "relative remote url", opt: WithRemoteURL("/api")},
+		{name: "invalid remote url", opt: WithRemoteURL("http://[::1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewClient("token", SandboxEnvironment, tt.opt); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestWithRemoteURL(t *testing.T) {
+	c, err := NewClient("token", LiveEnvironment, WithRemoteURL("http://localhost:8080"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.RemoteURL != "http://localhost:8080/" {
+		t.Errorf("expected the remote url to take precedence, got %s", c.RemoteURL)
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	var header http.Header
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{"customers":{"id":"CU123"}}`))
+	},
+		WithUserAgent("billing-service/1.0"),
+		WithHeader("X-Correlation-Id", "abc123"),
+		WithHeader("Authorization", "Bearer other"),
+	)
+	defer srv.Close()
+
+	if _, err := c.GetCustomer("CU123"); err != nil {
+		t.Fatal(err)
+	}
+	if header.Get("User-Agent") != "billing-service/1.0" || header.Get("X-Correlation-Id") != "abc123" {
+		t.Errorf("unexpected headers %v", header)
+	}
+	if got := header["Authorization"]; len(got) != 1 || got[0] != "Bearer token" {
+		t.Errorf("expected the client's Authorization header, got %v", got)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	hc := &http.Client{Timeout: time.Minute}
+	calls := 0
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"customers":{"id":"CU123"}}`))
+	}, WithHTTPClient(hc), WithTransport(transport), WithTimeout(time.Second))
+	defer srv.Close()
+
+	if _, err := c.GetCustomer("CU123"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the request to go through the transport, got %d calls", calls)
+	}
+	if hc.Transport != nil || hc.Timeout != time.Minute {
+		t.Error("expected the http client passed to WithHTTPClient to be left untouched")
+	}
+	if c.client().Timeout != time.Second {
+		t.Errorf("expected a timeout of 1s, got %s", c.client().Timeout)
+	}
+}
@@ -0,0 +1,131 @@
+package gocardless
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxListLimit is the largest page size accepted by list endpoints
+	maxListLimit = 500
+)
+
+// ListParams cursor pagination parameters accepted by every list endpoint.
+// Use Meta.NextPage and Meta.PreviousPage to move between pages.
+type ListParams struct {
+	// Limit Upper bound for the number of objects to be returned. Defaults to 50. Maximum of 500
+	Limit int
+	// Before ID of the object immediately following the array of objects to be returned
+	Before string
+	// After ID of the object immediately preceding the array of objects to be returned
+	After string
+}
+
+// values validates the parameters and encodes them to query string values
+func (p *ListParams) values() (url.Values, error) {
+	if p.Limit < 0 || p.Limit > maxListLimit {
+		return nil, fmt.Errorf("%w: limit must be between 0 (default) and %d", ErrInvalidParams, maxListLimit)
+	}
+	if p.Before != "" && p.After != "" {
+		return nil, fmt.Errorf("%w: before and after cannot be used together", ErrInvalidParams)
+	}
+
+	v := url.Values{}
+	if p.Limit > 0 {
+		v.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Before != "" {
+		v.Set("before", p.Before)
+	}
+	if p.After != "" {
+		v.Set("after", p.After)
+	}
+	return v, nil
+}
+
+// TimeRange filters a list on a timestamp such as created_at, unset bounds are ignored
+type TimeRange struct {
+	// GT limits to records created after the time
+	GT *time.Time
+	// GTE limits to records created on or after the time
+	GTE *time.Time
+	// LT limits to records created before the time
+	LT *time.Time
+	// LTE limits to records created on or before the time
+	LTE *time.Time
+}
+
+// encode validates the range and adds its bounds to v as name[gt], name[gte], ...
+func (r TimeRange) encode(v url.Values, name string) error {
+	return encodeRange(v, name, time.RFC3339, r.GT, r.GTE, r.LT, r.LTE)
+}
+
+// DateRange filters a list on a date such as charge_date, unset bounds are ignored
+type DateRange struct {
+	// GT limits to records on dates after the date
+	GT *Date
+	// GTE limits to records on or after the date
+	GTE *Date
+	// LT limits to records on dates before the date
+	LT *Date
+	// LTE limits to records on or before the date
+	LTE *Date
+}
+
+// encode validates the range and adds its bounds to v as name[gt], name[gte], ...
+func (r DateRange) encode(v url.Values, name string) error {
+	return encodeRange(v, name, "2006-01-02", r.GT.timePtr(), r.GTE.timePtr(), r.LT.timePtr(), r.LTE.timePtr())
+}
+
+func encodeRange(v url.Values, name, layout string, gt, gte, lt, lte *time.Time) error {
+	if gt != nil && gte != nil {
+		return fmt.Errorf("%w: %s cannot have both gt and gte bounds", ErrInvalidParams, name)
+	}
+	if lt != nil && lte != nil {
+		return fmt.Errorf("%w: %s cannot have both lt and lte bounds", ErrInvalidParams, name)
+	}
+
+	lower, upper := gt, lt
+	if lower == nil {
+		lower = gte
+	}
+	if upper == nil {
+		upper = lte
+	}
+	if lower != nil && upper != nil && lower.After(*upper) {
+		return fmt.Errorf("%w: %s lower bound is after its upper bound", ErrInvalidParams, name)
+	}
+
+	for op, t := range map[string]*time.Time{"gt": gt, "gte": gte, "lt": lt, "lte": lte} {
+		if t != nil {
+			v.Set(fmt.Sprintf("%s[%s]", name, op), t.Format(layout))
+		}
+	}
+	return nil
+}
+
+// setValue sets key to value in v unless value is empty
+func setValue(v url.Values, key, value string) {
+	if value != "" {
+		v.Set(key, value)
+	}
+}
+
+// listParams is implemented by the list parameters of every resource
+type listParams interface {
+	values() (url.Values, error)
+}
+
+// listPath appends the encoded params to the path of a list endpoint
+func listPath(endpoint string, params listParams) (string, error) {
+	v, err := params.values()
+	if err != nil {
+		return "", err
+	}
+	if len(v) == 0 {
+		return endpoint, nil
+	}
+	return fmt.Sprintf(`%s?%s`, endpoint, v.Encode()), nil
+}
@@ -0,0 +1,168 @@
+package gocardless
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestListParamsValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		params ListParams
+		want   string
+		err    bool
+	}{
+		{name: "empty", params: ListParams{}, want: ""},
+		{name: "limit", params: ListParams{Limit: 500, After: "PM1"}, want: "after=PM1&limit=500"},
+		{name: "negative limit", params: ListParams{Limit: -1}, err: true},
+		{name: "limit above maximum", params: ListParams{Limit: 501}, err: true},
+		{name: "before and after", params: ListParams{Before: "PM1", After: "PM2"}, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.params.values()
+			if tt.err {
+				if !errors.Is(err, ErrInvalidParams) {
+					t.Fatalf("expected ErrInvalidParams, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := v.Encode(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTimeRangeValidation(t *testing.T) {
+	early := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		r    TimeRange
+		want string
+		err  bool
+	}{
+		{name: "unset", r: TimeRange{}, want: ""},
+		{name: "bounds", r: TimeRange{GTE: &early, LT: &late}, want: "created_at%5Bgte%5D=2020-01-01T00%3A00%3A00Z&created_at%5Blt%5D=2020-02-01T00%3A00%3A00Z"},
+		{name: "gt and gte", r: TimeRange{GT: &early, GTE: &early}, err: true},
+		{name: "lt and lte", r: TimeRange{LT: &late, LTE: &late}, err: true},
+		{name: "lower after upper", r: TimeRange{GT: &late, LTE: &early}, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &PaymentListParams{CreatedAt: tt.r}
+			v, err := params.values()
+			if tt.err {
+				if !errors.Is(err, ErrInvalidParams) {
+					t.Fatalf("expected ErrInvalidParams, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := v.Encode(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDateRangeValidation(t *testing.T) {
+	early := &Date{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	late := &Date{time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)}
+
+	params := &PaymentListParams{ChargeDate: DateRange{GT: early, LTE: late}}
+	v, err := params.values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Get("charge_date[gt]") != "2020-01-01" || v.Get("charge_date[lte]") != "2020-02-01" {
+		t.Errorf("unexpected values %v", v)
+	}
+
+	params = &PaymentListParams{ChargeDate: DateRange{GTE: late, LT: early}}
+	if _, err := params.values(); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("expected ErrInvalidParams, got %v", err)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	var query string
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"payments":[],"meta":{"cursors":{},"limit":10}}`))
+	})
+	defer srv.Close()
+
+	_, err := c.GetPayments(&PaymentListParams{
+		ListParams: ListParams{Limit: 10},
+		Mandate:    "MD123",
+		Status:     "paid_out",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "limit=10&mandate=MD123&status=paid_out" {
+		t.Errorf("unexpected query %q", query)
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	var queries []string
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("after") == "" {
+			w.Write([]byte(`{"payments":[{"id":"PM1"}],"meta":{"cursors":{"after":"PM1"},"limit":1}}`))
+			return
+		}
+		w.Write([]byte(`{"payments":[{"id":"PM2"}],"meta":{"cursors":{"before":"PM2"},"limit":1}}`))
+	})
+	defer srv.Close()
+
+	first, err := c.GetPayments(&PaymentListParams{ListParams: ListParams{Limit: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.Meta.HasNext() || first.Meta.HasPrevious() {
+		t.Fatalf("expected only a next page, got %v", first.Meta)
+	}
+
+	second, err := c.GetPayments(&PaymentListParams{ListParams: first.Meta.NextPage()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Meta.HasNext() || !second.Meta.HasPrevious() {
+		t.Errorf("expected only a previous page, got %v", second.Meta)
+	}
+	if prev := second.Meta.PreviousPage(); prev.Before != "PM2" || prev.Limit != 1 {
+		t.Errorf("unexpected previous page %+v", prev)
+	}
+
+	if len(queries) != 2 || queries[0] != "limit=1" || queries[1] != "after=PM1&limit=1" {
+		t.Errorf("unexpected queries %q", queries)
+	}
+}
+
+func TestListParamsInvalid(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	defer srv.Close()
+
+	_, err := c.GetPayments(&PaymentListParams{ListParams: ListParams{Limit: 1000}})
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("expected ErrInvalidParams, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no request, got %d", calls)
+	}
+}
@@ -1,8 +1,10 @@
 package gocardless
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -42,21 +44,48 @@ type (
 		AppFee int `json:"app_fee,omitempty"`
 	}
 	paymentLinks struct {
-		CreditorID     string `json:"creditor,omitempty"`
-		PayoutID       string `json:"payout,omitempty"`
-		SubscriptionID string `json:"subscription,omitempty"`
-		MandateID      string `json:"mandate,omitempty"`
+		CreditorID           string `json:"creditor,omitempty"`
+		InstalmentScheduleID string `json:"instalment_schedule,omitempty"`
+		PayoutID             string `json:"payout,omitempty"`
+		SubscriptionID       string `json:"subscription,omitempty"`
+		MandateID            string `json:"mandate,omitempty"`
 	}
 	// paymentWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
 	paymentWrapper struct {
 		Payment *Payment `json:"payments"`
 	}
 
+	// PaymentListParams parameters for listing payments, nil lists the first page
+	PaymentListParams struct {
+		ListParams
+		// CreatedAt limits to payments created within the range
+		CreatedAt TimeRange
+		// ChargeDate limits to payments charged within the range
+		ChargeDate DateRange
+		// Creditor ID of a creditor to filter payments by
+		Creditor string
+		// Currency ISO 4217 currency code to filter payments by
+		Currency string
+		// Customer ID of a customer to filter payments by
+		Customer string
+		// Mandate ID of a mandate to filter payments by
+		Mandate string
+		// Subscription ID of a subscription to filter payments by
+		Subscription string
+		// Status status of the payments to return, e.g. "paid_out"
+		Status string
+	}
+
 	// PaymentListResponse a List response of Payment instances
 	PaymentListResponse struct {
 		Payments []*Payment `json:"payments"`
 		Meta     Meta       `json:"meta,omitempty"`
 	}
+
+	// PaymentIterator iterates over payments, see Client.IteratePayments
+	PaymentIterator struct {
+		*Iter
+	}
 )
 
 func (p *Payment) String() string {
@@ -78,13 +107,39 @@ func (p *Payment) AddMetadata(key, value string) {
 	p.Metadata[key] = value
 }
 
+func (p *PaymentListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+	if err := p.ChargeDate.encode(v, "charge_date"); err != nil {
+		return nil, err
+	}
+
+	setValue(v, "creditor", p.Creditor)
+	setValue(v, "currency", p.Currency)
+	setValue(v, "customer", p.Customer)
+	setValue(v, "mandate", p.Mandate)
+	setValue(v, "subscription", p.Subscription)
+	setValue(v, "status", p.Status)
+	return v, nil
+}
+
 // CreatePayment creates a new payment object.
 //
 // Relative endpoint: POST /payments
 func (c *Client) CreatePayment(payment *Payment) error {
+	return c.CreatePaymentContext(context.Background(), payment)
+}
+
+// CreatePaymentContext is the same as CreatePayment, but uses ctx for the request and applies opts to it.
+func (c *Client) CreatePaymentContext(ctx context.Context, payment *Payment, opts ...RequestOption) error {
 	paymentReq := &paymentWrapper{payment}
 
-	err := c.post(paymentEndpoint, paymentReq, paymentReq)
+	err := c.create(ctx, paymentEndpoint, paymentReq, paymentReq, opts...)
 	if err != nil {
 		return err
 	}
@@ -95,23 +150,76 @@ func (c *Client) CreatePayment(payment *Payment) error {
 // GetPayments returns a cursor-paginated list of your payments.
 //
 // Relative endpoint: GET /payments
-func (c *Client) GetPayments() (*PaymentListResponse, error) {
+func (c *Client) GetPayments(params *PaymentListParams) (*PaymentListResponse, error) {
+	return c.GetPaymentsContext(context.Background(), params)
+}
+
+// GetPaymentsContext is the same as GetPayments, but uses ctx for the request and applies opts to it.
+func (c *Client) GetPaymentsContext(ctx context.Context, params *PaymentListParams, opts ...RequestOption) (*PaymentListResponse, error) {
+	if params == nil {
+		params = &PaymentListParams{}
+	}
+	path, err := listPath(paymentEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
 	list := &PaymentListResponse{}
 
-	err := c.get(paymentEndpoint, list)
+	err = c.get(ctx, path, list, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return list, err
 }
 
+// IteratePayments returns an iterator over all your payments, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IteratePayments(params *PaymentListParams) *PaymentIterator {
+	return c.IteratePaymentsContext(context.Background(), params)
+}
+
+// IteratePaymentsContext is the same as IteratePayments, but uses ctx for the requests and applies opts to them.
+func (c *Client) IteratePaymentsContext(ctx context.Context, params *PaymentListParams, opts ...RequestOption) *PaymentIterator {
+	if params == nil {
+		params = &PaymentListParams{}
+	}
+	p := *params
+
+	return &PaymentIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetPaymentsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.Payments))
+		for i, v := range list.Payments {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// Payment returns the payment the iterator advanced to
+func (it *PaymentIterator) Payment() *Payment {
+	v, _ := it.Current().(*Payment)
+	return v
+}
+
 // GetPayment retrieves the details of an existing payment.
 //
 // Relative endpoint: GET /payments/PM123
 func (c *Client) GetPayment(id string) (*Payment, error) {
+	return c.GetPaymentContext(context.Background(), id)
+}
+
+// GetPaymentContext is the same as GetPayment, but uses ctx for the request and applies opts to it.
+func (c *Client) GetPaymentContext(ctx context.Context, id string, opts ...RequestOption) (*Payment, error) {
 	wrapper := &paymentWrapper{}
 
-	err := c.get(fmt.Sprintf(`%s/%s`, paymentEndpoint, id), wrapper)
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, paymentEndpoint, id), wrapper, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +230,11 @@ func (c *Client) GetPayment(id string) (*Payment, error) {
 //
 // Relative endpoint: PUT /payments/PM123
 func (c *Client) UpdatePayment(payment *Payment) error {
+	return c.UpdatePaymentContext(context.Background(), payment)
+}
+
+// UpdatePaymentContext is the same as UpdatePayment, but uses ctx for the request and applies opts to it.
+func (c *Client) UpdatePaymentContext(ctx context.Context, payment *Payment, opts ...RequestOption) error {
 	// allows only metadata
 	paymentMeta := map[string]interface{}{
 		"payments": map[string]interface{}{
@@ -131,7 +244,7 @@ func (c *Client) UpdatePayment(payment *Payment) error {
 
 	paymentReq := &paymentWrapper{payment}
 
-	err := c.put(fmt.Sprintf(`%s/%s`, paymentEndpoint, payment.ID), paymentMeta, paymentReq)
+	err := c.put(ctx, fmt.Sprintf(`%s/%s`, paymentEndpoint, payment.ID), paymentMeta, paymentReq, opts...)
 	if err != nil {
 		return err
 	}
@@ -142,6 +255,11 @@ func (c *Client) UpdatePayment(payment *Payment) error {
 //
 // Relative endpoint: POST /payments/PM123/actions/cancel
 func (c *Client) CancelPayment(payment *Payment) error {
+	return c.CancelPaymentContext(context.Background(), payment)
+}
+
+// CancelPaymentContext is the same as CancelPayment, but uses ctx for the request and applies opts to it.
+func (c *Client) CancelPaymentContext(ctx context.Context, payment *Payment, opts ...RequestOption) error {
 	// allows only metadata
 	pMeta := map[string]interface{}{
 		"payments": map[string]interface{}{
@@ -149,7 +267,7 @@ func (c *Client) CancelPayment(payment *Payment) error {
 		},
 	}
 	wrapper := &paymentWrapper{payment}
-	err := c.post(fmt.Sprintf(`%s/%s/actions/cancel`, paymentEndpoint, payment.ID), pMeta, wrapper)
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/cancel`, paymentEndpoint, payment.ID), pMeta, wrapper, opts...)
 	if err != nil {
 		return err
 	}
@@ -160,6 +278,11 @@ func (c *Client) CancelPayment(payment *Payment) error {
 //
 // Relative endpoint: POST /payments/PM123/actions/retry
 func (c *Client) RetryPayment(payment *Payment) error {
+	return c.RetryPaymentContext(context.Background(), payment)
+}
+
+// RetryPaymentContext is the same as RetryPayment, but uses ctx for the request and applies opts to it.
+func (c *Client) RetryPaymentContext(ctx context.Context, payment *Payment, opts ...RequestOption) error {
 	// allows only metadata
 	pMeta := map[string]interface{}{
 		"payments": map[string]interface{}{
@@ -167,7 +290,7 @@ func (c *Client) RetryPayment(payment *Payment) error {
 		},
 	}
 	wrapper := &paymentWrapper{payment}
-	err := c.post(fmt.Sprintf(`%s/%s/actions/retry`, paymentEndpoint, payment.ID), pMeta, wrapper)
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/retry`, paymentEndpoint, payment.ID), pMeta, wrapper, opts...)
 	if err != nil {
 		return err
 	}
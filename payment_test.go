@@ -0,0 +1,60 @@
+package gocardless
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPaymentMarshalChargeDate(t *testing.T) {
+	payment := &Payment{Amount: 1000, ChargeDate: &Date{time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)}}
+
+	bs, err := json.Marshal(payment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(bs), `"charge_date":"2020-01-02"`) {
+		t.Errorf("expected charge_date 2020-01-02, got %s", bs)
+	}
+
+	var decoded Payment
+	if err := json.Unmarshal(bs, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.ChargeDate.Equal(payment.ChargeDate.Time) {
+		t.Errorf("expected charge date %v, got %v", payment.ChargeDate, decoded.ChargeDate)
+	}
+
+	bs, err = json.Marshal(&Payment{Amount: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(bs), "charge_date") {
+		t.Errorf("expected no charge_date, got %s", bs)
+	}
+}
+
+func TestCreatePaymentChargeDate(t *testing.T) {
+	var body string
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		bs, _ := ioutil.ReadAll(r.Body)
+		body = string(bs)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"payments":{"id":"PM123","charge_date":"2020-01-02"}}`))
+	})
+	defer srv.Close()
+
+	payment := &Payment{Amount: 1000, ChargeDate: &Date{time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)}}
+	if err := c.CreatePayment(payment); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, `"charge_date":"2020-01-02"`) {
+		t.Errorf("expected charge_date 2020-01-02 to be sent, got %s", body)
+	}
+	if payment.ID != "PM123" {
+		t.Errorf("expected id PM123, got %q", payment.ID)
+	}
+}
@@ -0,0 +1,333 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	payoutEndpoint     = "payouts"
+	payoutItemEndpoint = "payout_items"
+)
+
+// Types of payout items, see PayoutItem.Type
+const (
+	PayoutItemTypePaymentPaidOut      = "payment_paid_out"
+	PayoutItemTypePaymentFailed       = "payment_failed"
+	PayoutItemTypePaymentChargedBack  = "payment_charged_back"
+	PayoutItemTypePaymentRefunded     = "payment_refunded"
+	PayoutItemTypeRefund              = "refund"
+	PayoutItemTypeRefundFundsReturned = "refund_funds_returned"
+	PayoutItemTypeGoCardlessFee       = "gocardless_fee"
+	PayoutItemTypeAppFee              = "app_fee"
+	PayoutItemTypeRevenueShare        = "revenue_share"
+	PayoutItemTypeSurchargeFee        = "surcharge_fee"
+)
+
+type (
+	// Payout Payouts represent transfers from GoCardless to a creditor. Each payout contains
+	// the funds collected from one or many payments, less fees, refunds and chargebacks.
+	Payout struct {
+		// ID is a unique identifier, beginning with "PO".
+		ID string `json:"id"`
+		// Amount in pence (GBP), cents (AUD/EUR), öre (SEK), or øre (DKK).
+		Amount int `json:"amount"`
+		// ArrivalDate Date the payout is due to arrive in the creditor’s bank account
+		ArrivalDate *Date `json:"arrival_date,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the payout was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Currency currency code
+		Currency string `json:"currency"`
+		// DeductedFees Fees that have already been deducted from the payout amount in pence/cents/öre/øre
+		DeductedFees int `json:"deducted_fees"`
+		// FX foreign exchange details of the payout, when the payout currency differs from the payments'
+		FX *PayoutFX `json:"fx,omitempty"`
+		// Metadata is a key-value store of custom data. Up to 3 keys are permitted, with key names up to 50
+		// characters and values up to 500 characters.
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// PayoutType Whether a payout contains merchant revenue or partner fees, "merchant" or "partner"
+		PayoutType string `json:"payout_type"`
+		// Reference appearing on the creditor’s bank statement
+		Reference string `json:"reference"`
+		// Status status of payout, one of "pending", "paid" or "bounced"
+		Status string `json:"status"`
+		// Links links to the creditor and the bank account the payout was sent to
+		Links payoutLinks `json:"links"`
+	}
+	// PayoutFX foreign exchange details of a payout
+	PayoutFX struct {
+		EstimatedExchangeRate string `json:"estimated_exchange_rate,omitempty"`
+		ExchangeRate          string `json:"exchange_rate,omitempty"`
+		FXAmount              int    `json:"fx_amount,omitempty"`
+		FXCurrency            string `json:"fx_currency,omitempty"`
+	}
+	payoutLinks struct {
+		CreditorID            string `json:"creditor,omitempty"`
+		CreditorBankAccountID string `json:"creditor_bank_account,omitempty"`
+	}
+	// payoutWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	payoutWrapper struct {
+		Payout *Payout `json:"payouts"`
+	}
+
+	// PayoutListParams parameters for listing payouts, nil lists the first page
+	PayoutListParams struct {
+		ListParams
+		// CreatedAt limits to payouts created within the range
+		CreatedAt TimeRange
+		// Creditor ID of a creditor to filter payouts by
+		Creditor string
+		// CreditorBankAccount ID of a creditor bank account to filter payouts by
+		CreditorBankAccount string
+		// Currency ISO 4217 currency code to filter payouts by
+		Currency string
+		// PayoutType limits to "merchant" or "partner" payouts
+		PayoutType string
+		// Reference payout reference to filter by
+		Reference string
+		// Status status of the payouts to return, e.g. "paid"
+		Status string
+	}
+
+	// PayoutListResponse a List response of Payout instances
+	PayoutListResponse struct {
+		Payouts []*Payout `json:"payouts"`
+		Meta    Meta      `json:"meta,omitempty"`
+	}
+
+	// PayoutIterator iterates over payouts, see Client.IteratePayouts
+	PayoutIterator struct {
+		*Iter
+	}
+
+	// PayoutItem a line of a payout: a payment paid out or one of the deductions from the
+	// payout such as fees, refunds and chargebacks
+	PayoutItem struct {
+		// Amount The positive (credit) or negative (debit) value of the item, in fractional
+		// currency to one decimal place, e.g. "-150.0"
+		Amount string `json:"amount"`
+		// Taxes taxes applied to fees deducted by GoCardless
+		Taxes []*PayoutItemTax `json:"taxes,omitempty"`
+		// Type type of the item, one of the PayoutItemType constants
+		Type string `json:"type"`
+		// Links links to the payment, mandate and refund the item relates to
+		Links payoutItemLinks `json:"links"`
+	}
+	// PayoutItemTax a tax applied to a fee
+	PayoutItemTax struct {
+		Amount              string `json:"amount"`
+		Currency            string `json:"currency"`
+		DestinationAmount   string `json:"destination_amount,omitempty"`
+		DestinationCurrency string `json:"destination_currency,omitempty"`
+		ExchangeRate        string `json:"exchange_rate,omitempty"`
+		TaxRateID           string `json:"tax_rate_id"`
+	}
+	payoutItemLinks struct {
+		MandateID string `json:"mandate,omitempty"`
+		PaymentID string `json:"payment,omitempty"`
+		RefundID  string `json:"refund,omitempty"`
+	}
+
+	// PayoutItemListParams parameters for listing the items of a payout
+	PayoutItemListParams struct {
+		ListParams
+		// Payout ID of the payout to list items of, required
+		Payout string
+	}
+
+	// PayoutItemListResponse a List response of PayoutItem instances
+	PayoutItemListResponse struct {
+		PayoutItems []*PayoutItem `json:"payout_items"`
+		Meta        Meta          `json:"meta,omitempty"`
+	}
+
+	// PayoutItemIterator iterates over the items of a payout, see Client.IteratePayoutItems
+	PayoutItemIterator struct {
+		*Iter
+	}
+)
+
+func (p *Payout) String() string {
+	bs, _ := json.Marshal(p)
+	return string(bs)
+}
+
+func (pi *PayoutItem) String() string {
+	bs, _ := json.Marshal(pi)
+	return string(bs)
+}
+
+func (p *PayoutListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+
+	setValue(v, "creditor", p.Creditor)
+	setValue(v, "creditor_bank_account", p.CreditorBankAccount)
+	setValue(v, "currency", p.Currency)
+	setValue(v, "payout_type", p.PayoutType)
+	setValue(v, "reference", p.Reference)
+	setValue(v, "status", p.Status)
+	return v, nil
+}
+
+func (p *PayoutItemListParams) values() (url.Values, error) {
+	if p.Payout == "" {
+		return nil, fmt.Errorf("%w: payout is required", ErrInvalidParams)
+	}
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+
+	v.Set("payout", p.Payout)
+	return v, nil
+}
+
+// GetPayouts returns a cursor-paginated list of your payouts.
+//
+// Relative endpoint: GET /payouts
+func (c *Client) GetPayouts(params *PayoutListParams) (*PayoutListResponse, error) {
+	return c.GetPayoutsContext(context.Background(), params)
+}
+
+// GetPayoutsContext is the same as GetPayouts, but uses ctx for the request and applies opts to it.
+func (c *Client) GetPayoutsContext(ctx context.Context, params *PayoutListParams, opts ...RequestOption) (*PayoutListResponse, error) {
+	if params == nil {
+		params = &PayoutListParams{}
+	}
+	path, err := listPath(payoutEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &PayoutListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IteratePayouts returns an iterator over all your payouts, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IteratePayouts(params *PayoutListParams) *PayoutIterator {
+	return c.IteratePayoutsContext(context.Background(), params)
+}
+
+// IteratePayoutsContext is the same as IteratePayouts, but uses ctx for the requests and applies opts to them.
+func (c *Client) IteratePayoutsContext(ctx context.Context, params *PayoutListParams, opts ...RequestOption) *PayoutIterator {
+	if params == nil {
+		params = &PayoutListParams{}
+	}
+	p := *params
+
+	return &PayoutIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetPayoutsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.Payouts))
+		for i, v := range list.Payouts {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// Payout returns the payout the iterator advanced to
+func (it *PayoutIterator) Payout() *Payout {
+	v, _ := it.Current().(*Payout)
+	return v
+}
+
+// GetPayout retrieves the details of an existing payout.
+//
+// Relative endpoint: GET /payouts/PO123
+func (c *Client) GetPayout(id string) (*Payout, error) {
+	return c.GetPayoutContext(context.Background(), id)
+}
+
+// GetPayoutContext is the same as GetPayout, but uses ctx for the request and applies opts to it.
+func (c *Client) GetPayoutContext(ctx context.Context, id string, opts ...RequestOption) (*Payout, error) {
+	wrapper := &payoutWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, payoutEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Payout, err
+}
+
+// GetPayoutItems returns a cursor-paginated list of the items of a payout.
+// params.Payout is required.
+//
+// Relative endpoint: GET /payout_items
+func (c *Client) GetPayoutItems(params *PayoutItemListParams) (*PayoutItemListResponse, error) {
+	return c.GetPayoutItemsContext(context.Background(), params)
+}
+
+// GetPayoutItemsContext is the same as GetPayoutItems, but uses ctx for the request and applies opts to it.
+func (c *Client) GetPayoutItemsContext(ctx context.Context, params *PayoutItemListParams, opts ...RequestOption) (*PayoutItemListResponse, error) {
+	if params == nil {
+		params = &PayoutItemListParams{}
+	}
+	path, err := listPath(payoutItemEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &PayoutItemListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IteratePayoutItems returns an iterator over all the items of a payout, including fees,
+// refunds and chargebacks deducted from it, fetching pages lazily as it advances.
+// params.Payout is required.
+func (c *Client) IteratePayoutItems(params *PayoutItemListParams) *PayoutItemIterator {
+	return c.IteratePayoutItemsContext(context.Background(), params)
+}
+
+// IteratePayoutItemsContext is the same as IteratePayoutItems, but uses ctx for the requests and applies opts to them.
+func (c *Client) IteratePayoutItemsContext(ctx context.Context, params *PayoutItemListParams, opts ...RequestOption) *PayoutItemIterator {
+	if params == nil {
+		params = &PayoutItemListParams{}
+	}
+	p := *params
+
+	return &PayoutItemIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetPayoutItemsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.PayoutItems))
+		for i, v := range list.PayoutItems {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// PayoutItem returns the payout item the iterator advanced to
+func (it *PayoutItemIterator) PayoutItem() *PayoutItem {
+	v, _ := it.Current().(*PayoutItem)
+	return v
+}
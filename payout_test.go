@@ -0,0 +1,124 @@
+package gocardless
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetPayouts(t *testing.T) {
+	var query string
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/payouts" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"payouts":[{"id":"PO123","amount":1000,"arrival_date":"2020-01-02","status":"paid","links":{"creditor":"CR123"}}],"meta":{"cursors":{},"limit":50}}`))
+	})
+	defer srv.Close()
+
+	res, err := c.GetPayouts(&PayoutListParams{Creditor: "CR123", Status: "paid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "creditor=CR123&status=paid" {
+		t.Errorf("unexpected query %q", query)
+	}
+	if len(res.Payouts) != 1 {
+		t.Fatalf("expected 1 payout, got %d", len(res.Payouts))
+	}
+	p := res.Payouts[0]
+	if p.ID != "PO123" || p.Amount != 1000 || p.ArrivalDate.Format("2006-01-02") != "2020-01-02" || p.Links.CreditorID != "CR123" {
+		t.Errorf("unexpected payout %v", p)
+	}
+}
+
+func TestGetPayout(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/payouts/PO123" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"payouts":{"id":"PO123","deducted_fees":20,"fx":{"exchange_rate":"1.1","fx_currency":"EUR"}}}`))
+	})
+	defer srv.Close()
+
+	p, err := c.GetPayout("PO123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "PO123" || p.DeductedFees != 20 || p.FX == nil || p.FX.FXCurrency != "EUR" {
+		t.Errorf("unexpected payout %v", p)
+	}
+}
+
+func TestGetPayoutItems(t *testing.T) {
+	var query string
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/payout_items" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"payout_items":[{"amount":"1000.0","type":"payment_paid_out","links":{"payment":"PM123"}},{"amount":"-20.0","type":"gocardless_fee","taxes":[{"amount":"4.0","currency":"GBP","tax_rate_id":"GB_VAT_1"}]}],"meta":{"cursors":{},"limit":50}}`))
+	})
+	defer srv.Close()
+
+	res, err := c.GetPayoutItems(&PayoutItemListParams{Payout: "PO123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "payout=PO123" {
+		t.Errorf("unexpected query %q", query)
+	}
+	if len(res.PayoutItems) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(res.PayoutItems))
+	}
+	if item := res.PayoutItems[0]; item.Type != PayoutItemTypePaymentPaidOut || item.Links.PaymentID != "PM123" {
+		t.Errorf("unexpected item %v", item)
+	}
+	if item := res.PayoutItems[1]; item.Type != PayoutItemTypeGoCardlessFee || len(item.Taxes) != 1 || item.Taxes[0].TaxRateID != "GB_VAT_1" {
+		t.Errorf("unexpected item %v", item)
+	}
+}
+
+func TestGetPayoutItemsRequiresPayout(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	})
+	defer srv.Close()
+
+	if _, err := c.GetPayoutItems(nil); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("expected ErrInvalidParams, got %v", err)
+	}
+	it := c.IteratePayoutItems(&PayoutItemListParams{})
+	if it.Next() || !errors.Is(it.Err(), ErrInvalidParams) {
+		t.Errorf("expected ErrInvalidParams, got %v", it.Err())
+	}
+	if calls != 0 {
+		t.Errorf("expected no request, got %d", calls)
+	}
+}
+
+func TestIteratePayoutItems(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") == "" {
+			w.Write([]byte(`{"payout_items":[{"amount":"1000.0","type":"payment_paid_out"}],"meta":{"cursors":{"after":"1"},"limit":1}}`))
+			return
+		}
+		w.Write([]byte(`{"payout_items":[{"amount":"-20.0","type":"gocardless_fee"}],"meta":{"cursors":{},"limit":1}}`))
+	})
+	defer srv.Close()
+
+	it := c.IteratePayoutItems(&PayoutItemListParams{Payout: "PO123"})
+	var amounts []string
+	for it.Next() {
+		amounts = append(amounts, it.PayoutItem().Amount)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(amounts) != "[1000.0 -20.0]" {
+		t.Errorf("unexpected items %v", amounts)
+	}
+}
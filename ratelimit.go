@@ -0,0 +1,110 @@
+package gocardless
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimitStats is a snapshot of the Client's view of the API rate limit,
+// as tracked from the RateLimit headers of every response
+type RateLimitStats struct {
+	// Limit is the number of requests allowed in each time window
+	Limit int
+	// Remaining is the number of requests the Client may still send in the current window
+	Remaining int
+	// Reset is the time at which the current window ends
+	Reset time.Time
+	// Waits is the number of requests that were held back because the window was exhausted
+	Waits int
+	// WaitTime is the total time requests were held back for
+	WaitTime time.Duration
+	// Throttled is the number of 429 Too Many Requests responses received
+	Throttled int
+}
+
+// rateLimiter is shared by all goroutines using a Client. It reserves one request from
+// the remaining budget before every request and blocks until the window resets once the
+// budget is exhausted.
+type rateLimiter struct {
+	mu    sync.Mutex
+	known bool
+	stats RateLimitStats
+}
+
+// wait blocks until a request may be sent or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		if !l.known || !now.Before(l.stats.Reset) {
+			// nothing is known about the current window until the next response arrives
+			l.known = false
+			l.mu.Unlock()
+			return nil
+		}
+		if l.stats.Remaining > 0 {
+			l.stats.Remaining--
+			l.mu.Unlock()
+			return nil
+		}
+
+		d := l.stats.Reset.Sub(now)
+		l.stats.Waits++
+		l.stats.WaitTime += d
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// update records the rate limit headers of resp
+func (l *rateLimiter) update(resp *Response) {
+	if l == nil {
+		return
+	}
+
+	limit := resp.RateLimit()
+	remaining := resp.RateLimitRemaining()
+	reset := resp.RateReset()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		l.stats.Throttled++
+		remaining = 0
+	}
+	if limit == 0 || reset.IsZero() {
+		return
+	}
+	// responses of concurrent requests arrive out of order, within the same
+	// window keep the lowest remaining count seen so far
+	if l.known && reset.Equal(l.stats.Reset) && remaining > l.stats.Remaining {
+		remaining = l.stats.Remaining
+	}
+
+	l.known = true
+	l.stats.Limit = limit
+	l.stats.Remaining = remaining
+	l.stats.Reset = reset
+}
+
+// RateLimitStats returns the current state of the Client's rate limiter.
+// The zero value is returned when the rate limiter is disabled.
+func (c *Client) RateLimitStats() RateLimitStats {
+	if c.limiter == nil {
+		return RateLimitStats{}
+	}
+
+	c.limiter.mu.Lock()
+	defer c.limiter.mu.Unlock()
+	return c.limiter.stats
+}
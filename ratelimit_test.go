@@ -0,0 +1,120 @@
+package gocardless
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// rateLimitHandler responds with the given rate limit headers, the window resets in a minute
+func rateLimitHandler(status, remaining int) http.HandlerFunc {
+	reset := time.Now().Add(time.Minute).UTC().Format(time.RFC1123)
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Limit", "1000")
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("RateLimit-Reset", reset)
+		w.WriteHeader(status)
+		w.Write([]byte(`{"customers":{"id":"CU123"}}`))
+	}
+}
+
+func TestRateLimiterBlocksWhenExhausted(t *testing.T) {
+	c, srv := newTestClient(t, rateLimitHandler(http.StatusOK, 0))
+	defer srv.Close()
+
+	if _, err := c.GetCustomer("CU123"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetCustomerContext(ctx, "CU123"); err != context.DeadlineExceeded {
+		t.Fatalf("expected the request to be held back until the deadline, got %v", err)
+	}
+
+	stats := c.RateLimitStats()
+	if stats.Limit != 1000 || stats.Remaining != 0 || stats.Waits != 1 || stats.WaitTime <= 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRateLimiterReservesRemaining(t *testing.T) {
+	c, srv := newTestClient(t, rateLimitHandler(http.StatusOK, 5))
+	defer srv.Close()
+
+	if _, err := c.GetCustomer("CU123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.limiter.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := c.RateLimitStats()
+	if stats.Remaining != 4 || stats.Waits != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRateLimiterCountsThrottled(t *testing.T) {
+	c, srv := newTestClient(t, rateLimitHandler(http.StatusTooManyRequests, 10))
+	defer srv.Close()
+
+	if _, err := c.GetCustomer("CU123"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	stats := c.RateLimitStats()
+	if stats.Throttled != 1 || stats.Remaining != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestRateLimiterWaitsForReset(t *testing.T) {
+	l := &rateLimiter{known: true}
+	l.stats.Reset = time.Now().Add(20 * time.Millisecond)
+
+	start := time.Now()
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected to wait for the reset, waited %s", elapsed)
+	}
+	if l.stats.Waits != 1 {
+		t.Errorf("expected 1 wait, got %d", l.stats.Waits)
+	}
+}
+
+func TestRateLimiterKeepsLowestRemaining(t *testing.T) {
+	reset := time.Now().Add(time.Minute).UTC().Truncate(time.Second)
+	response := func(remaining int) *Response {
+		resp := &Response{Response: &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}}
+		resp.Header.Set("RateLimit-Limit", "1000")
+		resp.Header.Set("RateLimit-Remaining", strconv.Itoa(remaining))
+		resp.Header.Set("RateLimit-Reset", reset.Format(time.RFC1123))
+		return resp
+	}
+
+	l := &rateLimiter{}
+	l.update(response(10))
+	l.update(response(12))
+	if l.stats.Remaining != 10 {
+		t.Errorf("expected the lowest remaining count, got %d", l.stats.Remaining)
+	}
+}
+
+func TestWithoutRateLimiter(t *testing.T) {
+	c, srv := newTestClient(t, rateLimitHandler(http.StatusOK, 0), WithoutRateLimiter())
+	defer srv.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetCustomer("CU123"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats := c.RateLimitStats(); stats != (RateLimitStats{}) {
+		t.Errorf("expected no stats, got %+v", stats)
+	}
+}
@@ -0,0 +1,169 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	redirectFlowEndpoint = "redirect_flows"
+)
+
+type (
+	// RedirectFlow Redirect flows enable you to use GoCardless’ hosted payment pages to set up mandates
+	// with your customers. The customer is sent to RedirectURL, enters their bank details and is sent
+	// back to SuccessRedirectURL, after which the flow must be completed with CompleteRedirectFlow
+	// to create the customer, customer bank account and mandate.
+	RedirectFlow struct {
+		// ID is a unique identifier, beginning with "RE".
+		ID string `json:"id,omitempty"`
+		// ConfirmationURL URL of a confirmation page to show the customer after the flow has been
+		// completed, only set after completion
+		ConfirmationURL string `json:"confirmation_url,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the redirect flow was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Description A description of the item the customer is paying for, shown on the hosted page
+		Description string `json:"description,omitempty"`
+		// MandateReference Mandate reference generated by GoCardless or submitted by an integrator
+		MandateReference string `json:"mandate_reference,omitempty"`
+		// Metadata is a key-value store of custom data. Up to 3 keys are permitted, with key names up to 50
+		// characters and values up to 500 characters.
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// PrefilledCustomer customer details used to pre-fill the hosted page, only used on creation
+		PrefilledCustomer *PrefilledCustomer `json:"prefilled_customer,omitempty"`
+		// RedirectURL The URL of the hosted payment pages for this redirect flow. This is the URL
+		// you should redirect your customer to.
+		RedirectURL string `json:"redirect_url,omitempty"`
+		// Scheme The Direct Debit scheme of the mandate. If specified, the payment pages will only
+		// allow the set-up of a mandate for the specified scheme.
+		Scheme string `json:"scheme,omitempty"`
+		// SessionToken The customer’s session ID, used to make sure the flow is completed by the
+		// customer who started it
+		SessionToken string `json:"session_token"`
+		// SuccessRedirectURL The URL to redirect to upon successful mandate setup
+		SuccessRedirectURL string `json:"success_redirect_url"`
+		// Links links to the creditor, and once completed to the created customer, bank account and mandate
+		Links redirectFlowLinks `json:"links"`
+	}
+	redirectFlowLinks struct {
+		CreditorID            string `json:"creditor,omitempty"`
+		CustomerID            string `json:"customer,omitempty"`
+		CustomerBankAccountID string `json:"customer_bank_account,omitempty"`
+		MandateID             string `json:"mandate,omitempty"`
+	}
+
+	// PrefilledCustomer customer details used to pre-fill the hosted payment pages of a redirect flow
+	PrefilledCustomer struct {
+		AddressLine1          string `json:"address_line1,omitempty"`
+		AddressLine2          string `json:"address_line2,omitempty"`
+		AddressLine3          string `json:"address_line3,omitempty"`
+		City                  string `json:"city,omitempty"`
+		CompanyName           string `json:"company_name,omitempty"`
+		CountryCode           string `json:"country_code,omitempty"`
+		DanishIdentityNumber  string `json:"danish_identity_number,omitempty"`
+		Email                 string `json:"email,omitempty"`
+		FamilyName            string `json:"family_name,omitempty"`
+		GivenName             string `json:"given_name,omitempty"`
+		Language              string `json:"language,omitempty"`
+		PhoneNumber           string `json:"phone_number,omitempty"`
+		PostalCode            string `json:"postal_code,omitempty"`
+		Region                string `json:"region,omitempty"`
+		SwedishIdentityNumber string `json:"swedish_identity_number,omitempty"`
+	}
+
+	// redirectFlowWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	redirectFlowWrapper struct {
+		RedirectFlow *RedirectFlow `json:"redirect_flows"`
+	}
+)
+
+func (rf *RedirectFlow) String() string {
+	bs, _ := json.Marshal(rf)
+	return string(bs)
+}
+
+// NewRedirectFlow instantiate a new redirect flow object
+func NewRedirectFlow(sessionToken, successRedirectURL string) *RedirectFlow {
+	return &RedirectFlow{
+		SessionToken:       sessionToken,
+		SuccessRedirectURL: successRedirectURL,
+	}
+}
+
+// AddMetadata adds new metadata item to redirect flow object
+func (rf *RedirectFlow) AddMetadata(key, value string) {
+	if rf.Metadata == nil {
+		rf.Metadata = make(map[string]string)
+	}
+	rf.Metadata[key] = value
+}
+
+// NewPayment instantiate a new payment against the mandate created by a completed redirect flow
+func (rf *RedirectFlow) NewPayment(amount int, currency string) *Payment {
+	return NewPayment(amount, currency, rf.Links.MandateID)
+}
+
+// CreateRedirectFlow creates a new redirect flow object, send the customer to its RedirectURL.
+//
+// Relative endpoint: POST /redirect_flows
+func (c *Client) CreateRedirectFlow(redirectFlow *RedirectFlow) error {
+	return c.CreateRedirectFlowContext(context.Background(), redirectFlow)
+}
+
+// CreateRedirectFlowContext is the same as CreateRedirectFlow, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateRedirectFlowContext(ctx context.Context, redirectFlow *RedirectFlow, opts ...RequestOption) error {
+	redirectFlowReq := &redirectFlowWrapper{redirectFlow}
+
+	err := c.create(ctx, redirectFlowEndpoint, redirectFlowReq, redirectFlowReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetRedirectFlow retrieves the details of a single redirect flow.
+//
+// Relative endpoint: GET /redirect_flows/RE123
+func (c *Client) GetRedirectFlow(id string) (*RedirectFlow, error) {
+	return c.GetRedirectFlowContext(context.Background(), id)
+}
+
+// GetRedirectFlowContext is the same as GetRedirectFlow, but uses ctx for the request and applies opts to it.
+func (c *Client) GetRedirectFlowContext(ctx context.Context, id string, opts ...RequestOption) (*RedirectFlow, error) {
+	wrapper := &redirectFlowWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, redirectFlowEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.RedirectFlow, err
+}
+
+// CompleteRedirectFlow completes a redirect flow once the customer is back at your
+// SuccessRedirectURL, creating the customer, customer bank account and mandate. Their
+// IDs are set in the Links of the returned redirect flow. sessionToken must match the
+// one the flow was created with.
+//
+// Relative endpoint: POST /redirect_flows/RE123/actions/complete
+func (c *Client) CompleteRedirectFlow(id, sessionToken string) (*RedirectFlow, error) {
+	return c.CompleteRedirectFlowContext(context.Background(), id, sessionToken)
+}
+
+// CompleteRedirectFlowContext is the same as CompleteRedirectFlow, but uses ctx for the request and applies opts to it.
+func (c *Client) CompleteRedirectFlowContext(ctx context.Context, id, sessionToken string, opts ...RequestOption) (*RedirectFlow, error) {
+	body := map[string]interface{}{
+		"data": map[string]interface{}{
+			"session_token": sessionToken,
+		},
+	}
+
+	wrapper := &redirectFlowWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/complete`, redirectFlowEndpoint, id), body, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.RedirectFlow, err
+}
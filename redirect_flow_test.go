@@ -0,0 +1,91 @@
+package gocardless
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCreateRedirectFlow(t *testing.T) {
+	var body map[string]map[string]interface{}
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/redirect_flows" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Idempotency-Key") == "" {
+			t.Error("expected an Idempotency-Key header")
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"redirect_flows":{"id":"RE123","session_token":"SESS123","redirect_url":"https://pay.gocardless.com/flow/RE123","links":{"creditor":"CR123"}}}`))
+	})
+	defer srv.Close()
+
+	flow := NewRedirectFlow("SESS123", "https://example.com/done")
+	flow.Description = "Gold plan"
+	flow.PrefilledCustomer = &PrefilledCustomer{Email: "user@example.com"}
+	if err := c.CreateRedirectFlow(flow); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"description":          "Gold plan",
+		"session_token":        "SESS123",
+		"success_redirect_url": "https://example.com/done",
+		"prefilled_customer":   map[string]interface{}{"email": "user@example.com"},
+		"links":                map[string]interface{}{},
+	}
+	if !reflect.DeepEqual(body["redirect_flows"], want) {
+		t.Errorf("expected %v, got %v", want, body["redirect_flows"])
+	}
+	if flow.ID != "RE123" || flow.RedirectURL != "https://pay.gocardless.com/flow/RE123" || flow.Links.CreditorID != "CR123" {
+		t.Errorf("unexpected redirect flow %v", flow)
+	}
+}
+
+func TestGetRedirectFlow(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/redirect_flows/RE123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"redirect_flows":{"id":"RE123","session_token":"SESS123","scheme":"bacs"}}`))
+	})
+	defer srv.Close()
+
+	flow, err := c.GetRedirectFlow("RE123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flow.ID != "RE123" || flow.Scheme != "bacs" {
+		t.Errorf("unexpected redirect flow %v", flow)
+	}
+}
+
+func TestCompleteRedirectFlow(t *testing.T) {
+	var body map[string]map[string]interface{}
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/redirect_flows/RE123/actions/complete" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"redirect_flows":{"id":"RE123","confirmation_url":"https://pay.gocardless.com/flow/RE123/success","links":{"customer":"CU123","customer_bank_account":"BA123","mandate":"MD123"}}}`))
+	})
+	defer srv.Close()
+
+	flow, err := c.CompleteRedirectFlow("RE123", "SESS123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body["data"]["session_token"] != "SESS123" {
+		t.Errorf("expected the session token to be sent, got %v", body)
+	}
+	if flow.Links.CustomerID != "CU123" || flow.Links.MandateID != "MD123" || flow.ConfirmationURL == "" {
+		t.Errorf("unexpected redirect flow %v", flow)
+	}
+
+	payment := flow.NewPayment(1000, "GBP")
+	if payment.Links.MandateID != "MD123" {
+		t.Errorf("expected a payment against MD123, got %v", payment)
+	}
+}
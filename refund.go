@@ -0,0 +1,251 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	refundEndpoint = "refunds"
+)
+
+type (
+	// Refund objects represent (partial) refunds of a payment back to the customer.
+	Refund struct {
+		// ID is a unique identifier, beginning with "RF".
+		ID string `json:"id,omitempty"`
+		// Amount in pence (GBP), cents (AUD/EUR), öre (SEK), or øre (DKK).
+		Amount int `json:"amount"`
+		// CreatedAt is a fixed timestamp, recording when the refund was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Currency currency code, set by the API to the currency of the refunded payment
+		Currency string `json:"currency,omitempty"`
+		// Metadata is a key-value store of custom data. Up to 3 keys are permitted, with key names up to 50
+		// characters and values up to 500 characters.
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// Reference An optional refund reference, displayed on your customer’s bank statement
+		Reference string `json:"reference,omitempty"`
+		// Status status of refund.
+		Status string `json:"status,omitempty"`
+		// TotalAmountConfirmation Total expected refunded amount in pence/cents/öre, including this
+		// refund. The refund is rejected unless it matches the amount refunded so far plus Amount,
+		// which protects against creating the same refund twice.
+		TotalAmountConfirmation int `json:"total_amount_confirmation,omitempty"`
+		// Links links to the refunded payment and its mandate
+		Links refundLinks `json:"links"`
+	}
+	refundLinks struct {
+		MandateID string `json:"mandate,omitempty"`
+		PaymentID string `json:"payment,omitempty"`
+	}
+	// refundWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	refundWrapper struct {
+		Refund *Refund `json:"refunds"`
+	}
+
+	// RefundListParams parameters for listing refunds, nil lists the first page
+	RefundListParams struct {
+		ListParams
+		// CreatedAt limits to refunds created within the range
+		CreatedAt TimeRange
+		// Mandate ID of a mandate to filter refunds by
+		Mandate string
+		// Payment ID of a payment to filter refunds by
+		Payment string
+		// RefundType limits to refunds of a "payment" or a "mandate"
+		RefundType string
+	}
+
+	// RefundListResponse a List response of Refund instances
+	RefundListResponse struct {
+		Refunds []*Refund `json:"refunds"`
+		Meta    Meta      `json:"meta,omitempty"`
+	}
+
+	// RefundIterator iterates over refunds, see Client.IterateRefunds
+	RefundIterator struct {
+		*Iter
+	}
+)
+
+func (r *Refund) String() string {
+	bs, _ := json.Marshal(r)
+	return string(bs)
+}
+
+// AddMetadata adds new metadata item to refund object
+func (r *Refund) AddMetadata(key, value string) {
+	if r.Metadata == nil {
+		r.Metadata = make(map[string]string)
+	}
+	r.Metadata[key] = value
+}
+
+// RefundableAmount returns the part of the payment which has not been refunded yet
+func (p *Payment) RefundableAmount() int {
+	return p.Amount - p.AmountRefunded
+}
+
+// NewRefund instantiate a new refund of amount against the payment. It returns
+// ErrRefundExceedsPayment when amount is more than RefundableAmount, and sets
+// TotalAmountConfirmation so that the API rejects the refund if the payment was
+// refunded in the meantime.
+func (p *Payment) NewRefund(amount int) (*Refund, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: refund amount must be positive", ErrInvalidParams)
+	}
+	if amount > p.RefundableAmount() {
+		return nil, fmt.Errorf("%w: %d requested, %d refundable", ErrRefundExceedsPayment, amount, p.RefundableAmount())
+	}
+
+	return &Refund{
+		Amount:                  amount,
+		TotalAmountConfirmation: p.AmountRefunded + amount,
+		Links:                   refundLinks{PaymentID: p.ID},
+	}, nil
+}
+
+func (p *RefundListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+	if p.Mandate != "" && p.Payment != "" {
+		return nil, fmt.Errorf("%w: mandate and payment cannot be used together", ErrInvalidParams)
+	}
+
+	setValue(v, "mandate", p.Mandate)
+	setValue(v, "payment", p.Payment)
+	setValue(v, "refund_type", p.RefundType)
+	return v, nil
+}
+
+// CreateRefund creates a new refund object. Use Payment.NewRefund to build a refund
+// which cannot exceed the refundable amount of its payment.
+//
+// Relative endpoint: POST /refunds
+func (c *Client) CreateRefund(refund *Refund) error {
+	return c.CreateRefundContext(context.Background(), refund)
+}
+
+// CreateRefundContext is the same as CreateRefund, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateRefundContext(ctx context.Context, refund *Refund, opts ...RequestOption) error {
+	refundReq := &refundWrapper{refund}
+
+	err := c.create(ctx, refundEndpoint, refundReq, refundReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetRefunds returns a cursor-paginated list of your refunds.
+//
+// Relative endpoint: GET /refunds
+func (c *Client) GetRefunds(params *RefundListParams) (*RefundListResponse, error) {
+	return c.GetRefundsContext(context.Background(), params)
+}
+
+// GetRefundsContext is the same as GetRefunds, but uses ctx for the request and applies opts to it.
+func (c *Client) GetRefundsContext(ctx context.Context, params *RefundListParams, opts ...RequestOption) (*RefundListResponse, error) {
+	if params == nil {
+		params = &RefundListParams{}
+	}
+	path, err := listPath(refundEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &RefundListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateRefunds returns an iterator over all your refunds, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateRefunds(params *RefundListParams) *RefundIterator {
+	return c.IterateRefundsContext(context.Background(), params)
+}
+
+// IterateRefundsContext is the same as IterateRefunds, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateRefundsContext(ctx context.Context, params *RefundListParams, opts ...RequestOption) *RefundIterator {
+	if params == nil {
+		params = &RefundListParams{}
+	}
+	p := *params
+
+	return &RefundIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetRefundsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.Refunds))
+		for i, v := range list.Refunds {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// Refund returns the refund the iterator advanced to
+func (it *RefundIterator) Refund() *Refund {
+	v, _ := it.Current().(*Refund)
+	return v
+}
+
+// GetRefund retrieves the details of an existing refund.
+//
+// Relative endpoint: GET /refunds/RF123
+func (c *Client) GetRefund(id string) (*Refund, error) {
+	return c.GetRefundContext(context.Background(), id)
+}
+
+// GetRefundContext is the same as GetRefund, but uses ctx for the request and applies opts to it.
+func (c *Client) GetRefundContext(ctx context.Context, id string, opts ...RequestOption) (*Refund, error) {
+	wrapper := &refundWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, refundEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Refund, err
+}
+
+// UpdateRefund Updates a refund object. Only the metadata parameter is allowed.
+//
+// Relative endpoint: PUT /refunds/RF123
+func (c *Client) UpdateRefund(refund *Refund) error {
+	return c.UpdateRefundContext(context.Background(), refund)
+}
+
+// UpdateRefundContext is the same as UpdateRefund, but uses ctx for the request and applies opts to it.
+func (c *Client) UpdateRefundContext(ctx context.Context, refund *Refund, opts ...RequestOption) error {
+	// allows only metadata
+	refundMeta := map[string]interface{}{
+		"refunds": map[string]interface{}{
+			"metadata": refund.Metadata,
+		},
+	}
+
+	refundReq := &refundWrapper{refund}
+
+	err := c.put(ctx, fmt.Sprintf(`%s/%s`, refundEndpoint, refund.ID), refundMeta, refundReq, opts...)
+	if err != nil {
+		return err
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package gocardless
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPaymentNewRefund(t *testing.T) {
+	payment := &Payment{ID: "PM123", Amount: 1000, AmountRefunded: 300}
+
+	tests := []struct {
+		name   string
+		amount int
+		err    error
+	}{
+		{name: "partial", amount: 200},
+		{name: "refundable amount", amount: 700},
+		{name: "above refundable amount", amount: 701, err: ErrRefundExceedsPayment},
+		{name: "zero", amount: 0, err: ErrInvalidParams},
+		{name: "negative", amount: -100, err: ErrInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refund, err := payment.NewRefund(tt.amount)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) || refund != nil {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if refund.Amount != tt.amount || refund.Links.PaymentID != "PM123" {
+				t.Errorf("unexpected refund %v", refund)
+			}
+			if refund.TotalAmountConfirmation != 300+tt.amount {
+				t.Errorf("expected a total amount confirmation of %d, got %d", 300+tt.amount, refund.TotalAmountConfirmation)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package gocardless
 
 import (
 	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,6 +14,10 @@ const (
 	rateLimitHeader          = `RateLimit-Limit`
 	rateLimitRemainingHeader = `RateLimit-Remaining`
 	rateLimitResetHeader     = `RateLimit-Reset`
+	requestIDHeader          = `X-Request-Id`
+
+	// maxErrorBodySize limits how much of an error response is read
+	maxErrorBodySize = 64 << 10
 )
 
 // Response response from the API request, providing access
@@ -19,6 +26,22 @@ type Response struct {
 	*http.Response
 }
 
+// ResponseMetadata describes the response to a single request, see RecordResponse
+type ResponseMetadata struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Header holds the response headers
+	Header http.Header
+	// RequestID is the ID GoCardless assigned to the request, quote it when contacting support
+	RequestID string
+	// RateLimit is the number of requests allowed in each time window
+	RateLimit int
+	// RateLimitRemaining is the number of requests left in the current time window
+	RateLimitRemaining int
+	// RateReset is the time after which the rate limit resets
+	RateReset time.Time
+}
+
 // Meta contains pagination cursor for list endpoints
 type Meta struct {
 	Cursors Cursor `json:"cursors"`
@@ -68,20 +91,41 @@ func (resp *Response) RateReset() time.Time {
 	return value
 }
 
-// bind decodes response and binds it to struct
+// RequestID the unique ID GoCardless assigned to the request
+func (resp *Response) RequestID() string {
+	return resp.Header.Get(requestIDHeader)
+}
+
+// metadata returns the ResponseMetadata of the response
+func (resp *Response) metadata() ResponseMetadata {
+	return ResponseMetadata{
+		StatusCode:         resp.StatusCode,
+		Header:             resp.Header,
+		RequestID:          resp.RequestID(),
+		RateLimit:          resp.RateLimit(),
+		RateLimitRemaining: resp.RateLimitRemaining(),
+		RateReset:          resp.RateReset(),
+	}
+}
+
+// rawBody is used as dst of requests whose response is not JSON, e.g. PDFs, to have
+// bind copy the body to w as is instead of decoding it
+type rawBody struct {
+	w io.Writer
+}
+
+// bind decodes response and binds it to struct, or copies it when dst is a rawBody
 func (resp *Response) bind(dst interface{}) error {
 
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		var errCtn errorContainer
-
-		err := json.NewDecoder(resp.Body).Decode(&errCtn)
-		if err != nil {
-			return err
-		}
+		return resp.error()
+	}
 
-		return errCtn.Error
+	if raw, ok := dst.(rawBody); ok {
+		_, err := io.Copy(raw.w, resp.Body)
+		return err
 	}
 
 	if dst != nil {
@@ -93,6 +137,72 @@ func (resp *Response) bind(dst interface{}) error {
 	return nil
 }
 
+// error decodes the API error held in the body of an unsuccessful response.
+// Bodies which are not a JSON error, e.g. from a proxy in front of the API, are
+// reported with the status code and the start of the body as message.
+func (resp *Response) error() error {
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		terr := &TransportError{Err: err}
+		if resp.Request != nil {
+			terr.Method, terr.URL = resp.Request.Method, resp.Request.URL.String()
+		}
+		return terr
+	}
+
+	var errCtn errorContainer
+	if err := json.Unmarshal(body, &errCtn); err != nil || errCtn.Error == nil {
+		message := strings.TrimSpace(string(body))
+		if message == "" {
+			message = http.StatusText(resp.StatusCode)
+		}
+		errCtn.Error = &Error{Message: message}
+		if resp.StatusCode >= http.StatusInternalServerError {
+			errCtn.Error.Type = ErrorTypeGoCardless
+		}
+	}
+
+	apiErr := errCtn.Error
+	if apiErr.Code == 0 {
+		apiErr.Code = resp.StatusCode
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.RequestID()
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{apiError: apiErr, Reset: resp.RateReset()}
+	}
+	return typedError(apiErr)
+}
+
+// discard drains and closes the response body so that the underlying
+// connection can be reused
+func (resp *Response) discard() {
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// HasNext reports whether there are objects after this page
+func (m Meta) HasNext() bool {
+	return m.Cursors.After != ""
+}
+
+// HasPrevious reports whether there are objects before this page
+func (m Meta) HasPrevious() bool {
+	return m.Cursors.Before != ""
+}
+
+// NextPage returns the ListParams fetching the page after this one
+func (m Meta) NextPage() ListParams {
+	return ListParams{Limit: m.Limit, After: m.Cursors.After}
+}
+
+// PreviousPage returns the ListParams fetching the page before this one
+func (m Meta) PreviousPage() ListParams {
+	return ListParams{Limit: m.Limit, Before: m.Cursors.Before}
+}
+
 func (m Meta) String() string {
 	bs, _ := json.Marshal(m)
 	return string(bs)
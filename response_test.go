@@ -0,0 +1,97 @@
+package gocardless
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRecordResponse(t *testing.T) {
+	reset := time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			body:   `{"mandates":{"id":"MD123"}}`,
+		},
+		{
+			name:   "failure",
+			status: http.StatusNotFound,
+			body:   `{"error":{"type":"invalid_api_usage","code":404,"errors":[{"reason":"resource_not_found"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "RQ123")
+				w.Header().Set("RateLimit-Limit", "1000")
+				w.Header().Set("RateLimit-Remaining", "999")
+				w.Header().Set("RateLimit-Reset", reset.Format(time.RFC1123))
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}, WithoutRateLimiter())
+			defer srv.Close()
+
+			var meta ResponseMetadata
+			_, err := c.GetMandateContext(context.Background(), "MD123", RecordResponse(&meta))
+			if (err != nil) != (tt.status != http.StatusOK) {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if meta.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, meta.StatusCode)
+			}
+			if meta.RequestID != "RQ123" {
+				t.Errorf("expected request id RQ123, got %q", meta.RequestID)
+			}
+			if meta.RateLimit != 1000 || meta.RateLimitRemaining != 999 {
+				t.Errorf("expected rate limit 999/1000, got %d/%d", meta.RateLimitRemaining, meta.RateLimit)
+			}
+			if !meta.RateReset.Equal(reset) {
+				t.Errorf("expected rate reset %v, got %v", reset, meta.RateReset)
+			}
+			if meta.Header.Get("X-Request-Id") != "RQ123" {
+				t.Errorf("expected response headers, got %v", meta.Header)
+			}
+		})
+	}
+}
+
+func TestRecordResponseRequestIDFromBody(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"type":"invalid_api_usage","code":404,"request_id":"RQ456","errors":[{"reason":"resource_not_found"}]}}`))
+	})
+	defer srv.Close()
+
+	var meta ResponseMetadata
+	if _, err := c.GetMandateContext(context.Background(), "MD123", RecordResponse(&meta)); err == nil {
+		t.Fatal("expected an error")
+	}
+	if meta.RequestID != "RQ456" {
+		t.Errorf("expected request id RQ456, got %q", meta.RequestID)
+	}
+}
+
+func TestRecordResponseWithoutResponse(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	srv.Close()
+
+	meta := ResponseMetadata{RequestID: "untouched"}
+	_, err := c.GetMandateContext(context.Background(), "MD123", RecordResponse(&meta))
+	var terr *TransportError
+	if !errors.As(err, &terr) {
+		t.Fatalf("expected a TransportError, got %#v", err)
+	}
+	if meta.RequestID != "untouched" || meta.StatusCode != 0 {
+		t.Errorf("expected meta to be untouched, got %+v", meta)
+	}
+}
@@ -0,0 +1,104 @@
+package gocardless
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// DefaultRetryPolicy is a sensible retry policy for most integrations,
+// enable it with WithRetryPolicy(DefaultRetryPolicy)
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// RetryPolicy controls how requests failing with a network error, a 429 or a 5xx
+// response are retried. GET, PUT and DELETE requests are always safe to retry.
+// POST requests creating a resource are retried with the same Idempotency-Key so
+// that the resource is never created twice, other POST requests such as actions
+// are never retried. When an earlier attempt did create the resource, the retry
+// fails with an idempotent creation conflict and Create methods return the created
+// resource instead, as they do with WithConflictResolution.
+//
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, it doubles on every following attempt
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts. It does not apply when waiting
+	// for the RateLimit-Reset time of a 429 response.
+	MaxBackoff time.Duration
+}
+
+// shouldRetry reports whether req may be sent again given the outcome of the last attempt,
+// create tells whether req creates a resource
+func (p RetryPolicy) shouldRetry(ctx context.Context, req *Request, create bool, resp *Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	case http.MethodPost:
+		if !create || req.Header.Get("Idempotency-Key") == "" {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if err != nil {
+		// only failures to reach the API are retried, not errors of middleware
+		var terr *TransportError
+		return errors.As(err, &terr)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff returns how long to wait before the next attempt. Rate limited responses
+// wait until the RateLimit-Reset time when the API provides one, otherwise the delay
+// grows exponentially with attempt and is randomised to avoid synchronised retries.
+func (p RetryPolicy) backoff(attempt int, resp *Response) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if reset := resp.RateReset(); !reset.IsZero() {
+			if wait := reset.Sub(time.Now()); wait > 0 {
+				return wait
+			}
+		}
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// equal jitter: wait between half and the full delay
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// sleepContext pauses for d or until ctx is done, whichever happens first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,171 @@
+package gocardless
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+
+const serverErrorBody = `{"error":{"type":"gocardless","code":500}}`
+
+func TestRetryServerErrors(t *testing.T) {
+	var keys []string
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(serverErrorBody))
+			return
+		}
+		w.Write([]byte(`{"payments":{"id":"PM123"}}`))
+	}, WithRetryPolicy(testRetryPolicy))
+	defer srv.Close()
+
+	payment := NewPayment(100, "GBP", "MD123")
+	if err := c.CreatePayment(payment); err != nil {
+		t.Fatal(err)
+	}
+	if payment.ID != "PM123" {
+		t.Errorf("expected PM123, got %s", payment.ID)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] || keys[1] != keys[2] {
+		t.Errorf("expected the same idempotency key on every attempt, got %v", keys)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(serverErrorBody))
+	}, WithRetryPolicy(testRetryPolicy))
+	defer srv.Close()
+
+	_, err := c.GetPayment("PM123")
+	var internalErr *GoCardlessInternalError
+	if !errors.As(err, &internalErr) {
+		t.Fatalf("expected a GoCardlessInternalError, got %v", err)
+	}
+	if calls != testRetryPolicy.MaxAttempts {
+		t.Errorf("expected %d attempts, got %d", testRetryPolicy.MaxAttempts, calls)
+	}
+}
+
+func TestRetrySkipsClientErrors(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error":{"type":"validation_failed","code":422}}`))
+	}, WithRetryPolicy(testRetryPolicy))
+	defer srv.Close()
+
+	if err := c.CreatePayment(NewPayment(100, "GBP", "MD123")); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetrySkipsActions(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/payments/PM123/actions/cancel" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(serverErrorBody))
+	}, WithRetryPolicy(testRetryPolicy))
+	defer srv.Close()
+
+	if err := c.CancelPayment(&Payment{ID: "PM123"}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected actions not to be retried, got %d attempts", calls)
+	}
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(serverErrorBody))
+	})
+	defer srv.Close()
+
+	if _, err := c.GetPayment("PM123"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		cancel()
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(serverErrorBody))
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Hour}))
+	defer srv.Close()
+
+	if _, err := c.GetPaymentContext(ctx, "PM123"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 10, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{9, time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			wait := p.backoff(tt.attempt, nil)
+			if wait < tt.max/2 || wait > tt.max {
+				t.Errorf("attempt %d: expected a backoff between %s and %s, got %s", tt.attempt, tt.max/2, tt.max, wait)
+			}
+		}
+	}
+
+	if wait := (RetryPolicy{}).backoff(1, nil); wait != 0 {
+		t.Errorf("expected no backoff for the zero policy, got %s", wait)
+	}
+}
+
+func TestRetryPolicyBackoffRateLimited(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	resp := &Response{Response: &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}}
+	resp.Header.Set("RateLimit-Reset", time.Now().Add(time.Minute).UTC().Format(time.RFC1123))
+
+	// the reset time is honoured even beyond MaxBackoff
+	if wait := p.backoff(1, resp); wait < 58*time.Second || wait > time.Minute {
+		t.Errorf("expected a backoff until the rate limit reset, got %s", wait)
+	}
+}
@@ -0,0 +1,350 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	subscriptionEndpoint = "subscriptions"
+
+	// IntervalUnitWeekly charges a subscription every Interval weeks
+	IntervalUnitWeekly = "weekly"
+	// IntervalUnitMonthly charges a subscription every Interval months
+	IntervalUnitMonthly = "monthly"
+	// IntervalUnitYearly charges a subscription every Interval years
+	IntervalUnitYearly = "yearly"
+)
+
+type (
+	// Subscription Subscriptions create payments according to a schedule.
+	Subscription struct {
+		// ID is a unique identifier, beginning with "SB".
+		ID string `json:"id,omitempty"`
+		// Amount in pence (GBP), cents (AUD/EUR), öre (SEK), or øre (DKK).
+		Amount int `json:"amount"`
+		// AppFee The amount to be deducted from each payment as the OAuth app’s fee, in pence/cents/öre/øre
+		AppFee int `json:"app_fee,omitempty"`
+		// Count The total number of payments that should be taken by this subscription.
+		// Cannot be used together with EndDate
+		Count int `json:"count,omitempty"`
+		// CreatedAt is a fixed timestamp, recording when the subscription was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Currency currency code, currently only GBP, EUR, SEK and DKK are supported
+		Currency string `json:"currency"`
+		// DayOfMonth As per RFC 2445. The day of the month to charge customers on. 1-28 or -1 to
+		// indicate the last day of the month
+		DayOfMonth int `json:"day_of_month,omitempty"`
+		// EndDate Date on or after which no further payments should be created.
+		// Cannot be used together with Count
+		EndDate *Date `json:"end_date,omitempty"`
+		// Interval Number of IntervalUnit between customer charge dates. Defaults to 1
+		Interval int `json:"interval,omitempty"`
+		// IntervalUnit The unit of time between customer charge dates. One of weekly, monthly or yearly
+		IntervalUnit string `json:"interval_unit"`
+		// Metadata is a key-value store of custom data. Up to 3 keys are permitted, with key names up to 50
+		// characters and values up to 500 characters.
+		Metadata map[string]string `json:"metadata,omitempty"`
+		// Month Name of the month on which to charge a customer. Must be lowercase.
+		// Only applies when the IntervalUnit is yearly
+		Month string `json:"month,omitempty"`
+		// Name Optional name for the subscription. This will be set as the description on each payment created
+		Name string `json:"name,omitempty"`
+		// PaymentReference An optional payment reference. This will be set as the reference on each payment created
+		PaymentReference string `json:"payment_reference,omitempty"`
+		// RetryIfPossible On failure, automatically retry payments using intelligent retries
+		RetryIfPossible bool `json:"retry_if_possible,omitempty"`
+		// StartDate The date on which the first payment should be charged. Must be on or after the
+		// mandate’s NextPossibleChargeDate. When blank, this will be set as the mandate’s NextPossibleChargeDate
+		StartDate *Date `json:"start_date,omitempty"`
+		// Status status of subscription.
+		Status string `json:"status,omitempty"`
+		// UpcomingPayments Up to 10 upcoming payments with their amounts and charge dates
+		UpcomingPayments []*UpcomingPayment `json:"upcoming_payments,omitempty"`
+		// Links links to the mandate the subscription charges
+		Links subscriptionLinks `json:"links"`
+	}
+	subscriptionLinks struct {
+		MandateID string `json:"mandate,omitempty"`
+	}
+
+	// UpcomingPayment a payment a subscription will create in the future
+	UpcomingPayment struct {
+		// Amount in pence (GBP), cents (AUD/EUR), öre (SEK), or øre (DKK).
+		Amount int `json:"amount"`
+		// ChargeDate the date on which this payment will be charged
+		ChargeDate *Date `json:"charge_date"`
+	}
+
+	// subscriptionWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	subscriptionWrapper struct {
+		Subscription *Subscription `json:"subscriptions"`
+	}
+
+	// SubscriptionListParams parameters for listing subscriptions, nil lists the first page
+	SubscriptionListParams struct {
+		ListParams
+		// CreatedAt limits to subscriptions created within the range
+		CreatedAt TimeRange
+		// Customer ID of a customer to filter subscriptions by
+		Customer string
+		// Mandate ID of a mandate to filter subscriptions by
+		Mandate string
+		// Status subscription statuses to filter by, e.g. "active"
+		Status []string
+	}
+
+	// SubscriptionListResponse a List response of Subscription instances
+	SubscriptionListResponse struct {
+		Subscriptions []*Subscription `json:"subscriptions"`
+		Meta          Meta            `json:"meta,omitempty"`
+	}
+
+	// SubscriptionIterator iterates over subscriptions, see Client.IterateSubscriptions
+	SubscriptionIterator struct {
+		*Iter
+	}
+)
+
+func (s *Subscription) String() string {
+	bs, _ := json.Marshal(s)
+	return string(bs)
+}
+
+// NewSubscription instantiate new subscription object, intervalUnit is one of
+// IntervalUnitWeekly, IntervalUnitMonthly or IntervalUnitYearly
+func NewSubscription(amount int, currency, intervalUnit, mandateID string) *Subscription {
+	return &Subscription{
+		Amount:       amount,
+		Currency:     currency,
+		IntervalUnit: intervalUnit,
+		Links:        subscriptionLinks{MandateID: mandateID},
+	}
+}
+
+// AddMetadata adds new metadata item to subscription object
+func (s *Subscription) AddMetadata(key, value string) {
+	if s.Metadata == nil {
+		s.Metadata = make(map[string]string)
+	}
+	s.Metadata[key] = value
+}
+
+func (p *SubscriptionListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+
+	setValue(v, "customer", p.Customer)
+	setValue(v, "mandate", p.Mandate)
+	setValue(v, "status", strings.Join(p.Status, ","))
+	return v, nil
+}
+
+// CreateSubscription creates a new subscription object.
+//
+// Relative endpoint: POST /subscriptions
+func (c *Client) CreateSubscription(subscription *Subscription) error {
+	return c.CreateSubscriptionContext(context.Background(), subscription)
+}
+
+// CreateSubscriptionContext is the same as CreateSubscription, but uses ctx for the request and applies opts to it.
+func (c *Client) CreateSubscriptionContext(ctx context.Context, subscription *Subscription, opts ...RequestOption) error {
+	subscriptionReq := &subscriptionWrapper{subscription}
+
+	err := c.create(ctx, subscriptionEndpoint, subscriptionReq, subscriptionReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// GetSubscriptions returns a cursor-paginated list of your subscriptions.
+//
+// Relative endpoint: GET /subscriptions
+func (c *Client) GetSubscriptions(params *SubscriptionListParams) (*SubscriptionListResponse, error) {
+	return c.GetSubscriptionsContext(context.Background(), params)
+}
+
+// GetSubscriptionsContext is the same as GetSubscriptions, but uses ctx for the request and applies opts to it.
+func (c *Client) GetSubscriptionsContext(ctx context.Context, params *SubscriptionListParams, opts ...RequestOption) (*SubscriptionListResponse, error) {
+	if params == nil {
+		params = &SubscriptionListParams{}
+	}
+	path, err := listPath(subscriptionEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &SubscriptionListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateSubscriptions returns an iterator over all your subscriptions, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set.
+func (c *Client) IterateSubscriptions(params *SubscriptionListParams) *SubscriptionIterator {
+	return c.IterateSubscriptionsContext(context.Background(), params)
+}
+
+// IterateSubscriptionsContext is the same as IterateSubscriptions, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateSubscriptionsContext(ctx context.Context, params *SubscriptionListParams, opts ...RequestOption) *SubscriptionIterator {
+	if params == nil {
+		params = &SubscriptionListParams{}
+	}
+	p := *params
+
+	return &SubscriptionIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetSubscriptionsContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.Subscriptions))
+		for i, v := range list.Subscriptions {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// Subscription returns the subscription the iterator advanced to
+func (it *SubscriptionIterator) Subscription() *Subscription {
+	v, _ := it.Current().(*Subscription)
+	return v
+}
+
+// GetSubscription retrieves the details of an existing subscription.
+//
+// Relative endpoint: GET /subscriptions/SB123
+func (c *Client) GetSubscription(id string) (*Subscription, error) {
+	return c.GetSubscriptionContext(context.Background(), id)
+}
+
+// GetSubscriptionContext is the same as GetSubscription, but uses ctx for the request and applies opts to it.
+func (c *Client) GetSubscriptionContext(ctx context.Context, id string, opts ...RequestOption) (*Subscription, error) {
+	wrapper := &subscriptionWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, subscriptionEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Subscription, err
+}
+
+// UpdateSubscription Updates a subscription object. Only the name, payment reference, amount,
+// app fee and metadata can be changed.
+//
+// Relative endpoint: PUT /subscriptions/SB123
+func (c *Client) UpdateSubscription(subscription *Subscription) error {
+	return c.UpdateSubscriptionContext(context.Background(), subscription)
+}
+
+// UpdateSubscriptionContext is the same as UpdateSubscription, but uses ctx for the request and applies opts to it.
+func (c *Client) UpdateSubscriptionContext(ctx context.Context, subscription *Subscription, opts ...RequestOption) error {
+	// remove unpermitted keys before update
+	fields := map[string]interface{}{
+		"metadata": subscription.Metadata,
+	}
+	if subscription.Amount != 0 {
+		fields["amount"] = subscription.Amount
+	}
+	if subscription.Name != "" {
+		fields["name"] = subscription.Name
+	}
+	if subscription.PaymentReference != "" {
+		fields["payment_reference"] = subscription.PaymentReference
+	}
+	if subscription.AppFee != 0 {
+		fields["app_fee"] = subscription.AppFee
+	}
+	subMeta := map[string]interface{}{
+		"subscriptions": fields,
+	}
+
+	subscriptionReq := &subscriptionWrapper{subscription}
+
+	err := c.put(ctx, fmt.Sprintf(`%s/%s`, subscriptionEndpoint, subscription.ID), subMeta, subscriptionReq, opts...)
+	if err != nil {
+		return err
+	}
+	return err
+}
+
+// PauseSubscription pauses a subscription, no payments are created while it is paused.
+// pauseCycles is the number of cycles to pause for, after which the subscription resumes
+// by itself. Use 0 to pause until ResumeSubscription is called.
+//
+// Relative endpoint: POST /subscriptions/SB123/actions/pause
+func (c *Client) PauseSubscription(id string, pauseCycles int) (*Subscription, error) {
+	return c.PauseSubscriptionContext(context.Background(), id, pauseCycles)
+}
+
+// PauseSubscriptionContext is the same as PauseSubscription, but uses ctx for the request and applies opts to it.
+func (c *Client) PauseSubscriptionContext(ctx context.Context, id string, pauseCycles int, opts ...RequestOption) (*Subscription, error) {
+	var body interface{}
+	if pauseCycles > 0 {
+		body = map[string]interface{}{
+			"data": map[string]interface{}{
+				"pause_cycles": pauseCycles,
+			},
+		}
+	}
+
+	wrapper := &subscriptionWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/pause`, subscriptionEndpoint, id), body, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Subscription, err
+}
+
+// ResumeSubscription resumes a paused subscription.
+//
+// Relative endpoint: POST /subscriptions/SB123/actions/resume
+func (c *Client) ResumeSubscription(id string) (*Subscription, error) {
+	return c.ResumeSubscriptionContext(context.Background(), id)
+}
+
+// ResumeSubscriptionContext is the same as ResumeSubscription, but uses ctx for the request and applies opts to it.
+func (c *Client) ResumeSubscriptionContext(ctx context.Context, id string, opts ...RequestOption) (*Subscription, error) {
+	wrapper := &subscriptionWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/resume`, subscriptionEndpoint, id), nil, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Subscription, err
+}
+
+// CancelSubscription immediately cancels a subscription, no more payments will be created.
+// Payments already created are not cancelled.
+//
+// Relative endpoint: POST /subscriptions/SB123/actions/cancel
+func (c *Client) CancelSubscription(id string) (*Subscription, error) {
+	return c.CancelSubscriptionContext(context.Background(), id)
+}
+
+// CancelSubscriptionContext is the same as CancelSubscription, but uses ctx for the request and applies opts to it.
+func (c *Client) CancelSubscriptionContext(ctx context.Context, id string, opts ...RequestOption) (*Subscription, error) {
+	wrapper := &subscriptionWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/cancel`, subscriptionEndpoint, id), nil, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Subscription, err
+}
@@ -0,0 +1,49 @@
+package gocardless
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateSubscriptionBody(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription *Subscription
+		want         map[string]interface{}
+	}{
+		{
+			name:         "name only",
+			subscription: &Subscription{ID: "SB123", Name: "Gold plan", Currency: "GBP"},
+			want:         map[string]interface{}{"name": "Gold plan", "metadata": nil},
+		},
+		{
+			name:         "amount and metadata",
+			subscription: &Subscription{ID: "SB123", Amount: 1500, Metadata: map[string]string{"plan": "gold"}},
+			want:         map[string]interface{}{"amount": float64(1500), "metadata": map[string]interface{}{"plan": "gold"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]map[string]interface{}
+			c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut || r.URL.Path != "/subscriptions/SB123" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				w.Write([]byte(`{"subscriptions":{"id":"SB123","amount":1500}}`))
+			})
+			defer srv.Close()
+
+			if err := c.UpdateSubscription(tt.subscription); err != nil {
+				t.Fatal(err)
+			}
+			if got := body["subscriptions"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
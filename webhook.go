@@ -0,0 +1,205 @@
+package gocardless
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	webhookEndpoint = "webhooks"
+)
+
+type (
+	// Webhook Basic description of a webhook delivery, with the request sent to your endpoint
+	// and the response it returned. Use it to find failed deliveries and retry them.
+	Webhook struct {
+		// ID is a unique identifier, beginning with "WB".
+		ID string `json:"id"`
+		// CreatedAt is a fixed timestamp, recording when the webhook was created.
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// IsTest whether the webhook was sent from the developer tools of the dashboard
+		IsTest bool `json:"is_test"`
+		// RequestBody the body of the request sent to the webhook URL, see Events
+		RequestBody string `json:"request_body"`
+		// RequestHeaders the headers of the request sent to the webhook URL
+		RequestHeaders map[string]string `json:"request_headers,omitempty"`
+		// ResponseBody the body of the response from the webhook URL
+		ResponseBody string `json:"response_body"`
+		// ResponseBodyTruncated whether ResponseBody was truncated
+		ResponseBodyTruncated bool `json:"response_body_truncated"`
+		// ResponseCode the HTTP status code of the response from the webhook URL
+		ResponseCode int `json:"response_code"`
+		// ResponseHeaders the headers of the response from the webhook URL
+		ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+		// ResponseHeadersContentTruncated whether the values of ResponseHeaders were truncated
+		ResponseHeadersContentTruncated bool `json:"response_headers_content_truncated"`
+		// ResponseHeadersCountTruncated whether some of the response headers were left out
+		ResponseHeadersCountTruncated bool `json:"response_headers_count_truncated"`
+		// Successful whether the webhook URL responded with a 2xx status code
+		Successful bool `json:"successful"`
+		// URL the URL the webhook was sent to
+		URL string `json:"url"`
+	}
+
+	// webhookWrapper is a utility struct used to wrap and unwrap the JSON request being passed to the remote API
+	webhookWrapper struct {
+		Webhook *Webhook `json:"webhooks"`
+	}
+
+	// WebhookListParams parameters for listing webhooks, nil lists the first page
+	WebhookListParams struct {
+		ListParams
+		// CreatedAt limits to webhooks created within the range
+		CreatedAt TimeRange
+		// IsTest when set, limits to test or live webhooks
+		IsTest *bool
+		// Successful when set, limits to successful or failed webhooks
+		Successful *bool
+	}
+
+	// WebhookListResponse a List response of Webhook instances
+	WebhookListResponse struct {
+		Webhooks []*Webhook `json:"webhooks"`
+		Meta     Meta       `json:"meta,omitempty"`
+	}
+
+	// WebhookIterator iterates over webhooks, see Client.IterateWebhooks
+	WebhookIterator struct {
+		*Iter
+	}
+)
+
+func (w *Webhook) String() string {
+	bs, _ := json.Marshal(w)
+	return string(bs)
+}
+
+// Events decodes the events delivered by the webhook from its RequestBody
+func (w *Webhook) Events() ([]*Event, error) {
+	var body struct {
+		Events []*Event `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(w.RequestBody), &body); err != nil {
+		return nil, err
+	}
+	return body.Events, nil
+}
+
+func (p *WebhookListParams) values() (url.Values, error) {
+	v, err := p.ListParams.values()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreatedAt.encode(v, "created_at"); err != nil {
+		return nil, err
+	}
+
+	if p.IsTest != nil {
+		v.Set("is_test", strconv.FormatBool(*p.IsTest))
+	}
+	if p.Successful != nil {
+		v.Set("successful", strconv.FormatBool(*p.Successful))
+	}
+	return v, nil
+}
+
+// GetWebhooks returns a cursor-paginated list of your webhooks.
+//
+// Relative endpoint: GET /webhooks
+func (c *Client) GetWebhooks(params *WebhookListParams) (*WebhookListResponse, error) {
+	return c.GetWebhooksContext(context.Background(), params)
+}
+
+// GetWebhooksContext is the same as GetWebhooks, but uses ctx for the request and applies opts to it.
+func (c *Client) GetWebhooksContext(ctx context.Context, params *WebhookListParams, opts ...RequestOption) (*WebhookListResponse, error) {
+	if params == nil {
+		params = &WebhookListParams{}
+	}
+	path, err := listPath(webhookEndpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &WebhookListResponse{}
+
+	err = c.get(ctx, path, list, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return list, err
+}
+
+// IterateWebhooks returns an iterator over all your webhooks, fetching pages lazily
+// as it advances. params filters the list and sets the page size, iteration starts after
+// ListParams.After when set. Set params.Successful to false to find the failed deliveries.
+func (c *Client) IterateWebhooks(params *WebhookListParams) *WebhookIterator {
+	return c.IterateWebhooksContext(context.Background(), params)
+}
+
+// IterateWebhooksContext is the same as IterateWebhooks, but uses ctx for the requests and applies opts to them.
+func (c *Client) IterateWebhooksContext(ctx context.Context, params *WebhookListParams, opts ...RequestOption) *WebhookIterator {
+	if params == nil {
+		params = &WebhookListParams{}
+	}
+	p := *params
+
+	return &WebhookIterator{newIter(ctx, p.ListParams, func(ctx context.Context, lp ListParams) ([]interface{}, Meta, error) {
+		p.ListParams = lp
+		list, err := c.GetWebhooksContext(ctx, &p, opts...)
+		if err != nil {
+			return nil, Meta{}, err
+		}
+
+		items := make([]interface{}, len(list.Webhooks))
+		for i, v := range list.Webhooks {
+			items[i] = v
+		}
+		return items, list.Meta, nil
+	})}
+}
+
+// Webhook returns the webhook the iterator advanced to
+func (it *WebhookIterator) Webhook() *Webhook {
+	v, _ := it.Current().(*Webhook)
+	return v
+}
+
+// GetWebhook retrieves the details of an existing webhook, including the request and response bodies.
+//
+// Relative endpoint: GET /webhooks/WB123
+func (c *Client) GetWebhook(id string) (*Webhook, error) {
+	return c.GetWebhookContext(context.Background(), id)
+}
+
+// GetWebhookContext is the same as GetWebhook, but uses ctx for the request and applies opts to it.
+func (c *Client) GetWebhookContext(ctx context.Context, id string, opts ...RequestOption) (*Webhook, error) {
+	wrapper := &webhookWrapper{}
+
+	err := c.get(ctx, fmt.Sprintf(`%s/%s`, webhookEndpoint, id), wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Webhook, err
+}
+
+// RetryWebhook requests for a previous webhook to be sent again. The returned webhook
+// is the new delivery.
+//
+// Relative endpoint: POST /webhooks/WB123/actions/retry
+func (c *Client) RetryWebhook(id string) (*Webhook, error) {
+	return c.RetryWebhookContext(context.Background(), id)
+}
+
+// RetryWebhookContext is the same as RetryWebhook, but uses ctx for the request and applies opts to it.
+func (c *Client) RetryWebhookContext(ctx context.Context, id string, opts ...RequestOption) (*Webhook, error) {
+	wrapper := &webhookWrapper{}
+	err := c.post(ctx, fmt.Sprintf(`%s/%s/actions/retry`, webhookEndpoint, id), nil, wrapper, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return wrapper.Webhook, err
+}
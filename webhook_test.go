@@ -0,0 +1,89 @@
+package gocardless
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetWebhooks(t *testing.T) {
+	falseValue, trueValue := false, true
+	tests := []struct {
+		name   string
+		params *WebhookListParams
+		query  string
+	}{
+		{name: "all", params: nil, query: ""},
+		{name: "failed", params: &WebhookListParams{Successful: &falseValue}, query: "successful=false"},
+		{name: "live failed", params: &WebhookListParams{IsTest: &falseValue, Successful: &falseValue}, query: "is_test=false&successful=false"},
+		{name: "test", params: &WebhookListParams{ListParams: ListParams{Limit: 10}, IsTest: &trueValue}, query: "is_test=true&limit=10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/webhooks" || r.URL.RawQuery != tt.query {
+					t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+				}
+				w.Write([]byte(`{"webhooks":[{"id":"WB123","successful":false,"response_code":500}],"meta":{"cursors":{},"limit":50}}`))
+			})
+			defer srv.Close()
+
+			res, err := c.GetWebhooks(tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Webhooks) != 1 || res.Webhooks[0].ID != "WB123" || res.Webhooks[0].ResponseCode != 500 {
+				t.Errorf("unexpected webhooks %v", res.Webhooks)
+			}
+		})
+	}
+}
+
+func TestGetWebhook(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/webhooks/WB123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"webhooks":{"id":"WB123","url":"https://example.com/webhooks","successful":true,` +
+			`"request_body":"{\"events\":[{\"id\":\"EV123\",\"action\":\"paid_out\",\"resource_type\":\"payments\",\"links\":{\"payment\":\"PM123\"}}]}"}}`))
+	})
+	defer srv.Close()
+
+	webhook, err := c.GetWebhook("WB123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !webhook.Successful || webhook.URL != "https://example.com/webhooks" {
+		t.Errorf("unexpected webhook %v", webhook)
+	}
+
+	events, err := webhook.Events()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != "EV123" || events[0].Links.PaymentID != "PM123" {
+		t.Errorf("unexpected events %v", events)
+	}
+}
+
+func TestRetryWebhook(t *testing.T) {
+	calls := 0
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost || r.URL.Path != "/webhooks/WB123/actions/retry" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"webhooks":{"id":"WB456","successful":true,"response_code":200}}`))
+	})
+	defer srv.Close()
+
+	webhook, err := c.RetryWebhook("WB123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if webhook.ID != "WB456" || !webhook.Successful {
+		t.Errorf("expected the new delivery, got %v", webhook)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 request, got %d", calls)
+	}
+}